@@ -0,0 +1,104 @@
+package domain
+
+import "errors"
+
+// BankResourcesPerType is the number of cards of every resource in the bank at the start of the base game
+const BankResourcesPerType = 19
+
+// BankIsOutOfResourcesErr is used when the bank doesn't have enough cards to give
+var BankIsOutOfResourcesErr = errors.New("bank is out of resources")
+
+var allResourceCards = []ResourceCard{ResourceCardOre, ResourceCardWheat, ResourceCardSheep, ResourceCardBrick, ResourceCardWood}
+
+func newBankResources(resourcesPerType int64) map[ResourceCard]int64 {
+	bankResources := make(map[ResourceCard]int64, len(allResourceCards))
+
+	for _, resource := range allResourceCards {
+		bankResources[resource] = resourcesPerType
+	}
+
+	return bankResources
+}
+
+// BankResources returns the number of cards of every resource left in the bank
+func (game Game) BankResources() map[ResourceCard]int64 {
+	bankResources := make(map[ResourceCard]int64, len(game.bankResources))
+
+	for resource, count := range game.bankResources {
+		bankResources[resource] = count
+	}
+
+	return bankResources
+}
+
+// bankHasResources checks the bank can give the resources
+func (game Game) bankHasResources(resources []ResourceCard) error {
+	resourcesTypeCount := make(map[ResourceCard]int64)
+
+	for _, resource := range resources {
+		resourcesTypeCount[resource]++
+
+		if resourcesTypeCount[resource] > game.bankResources[resource] {
+			return BankIsOutOfResourcesErr
+		}
+	}
+
+	return nil
+}
+
+func (game *Game) takeFromBank(resources []ResourceCard) {
+	for _, resource := range resources {
+		game.bankResources[resource]--
+	}
+}
+
+func (game *Game) returnToBank(resources []ResourceCard) {
+	for _, resource := range resources {
+		game.bankResources[resource]++
+	}
+}
+
+// buy returns the player paid for the buyable, the cards go back to the bank
+func (game *Game) buy(player Player, buyable Buyable) Player {
+	game.returnToBank(buyable.Cost())
+
+	return player.Buy(buyable)
+}
+
+// limitByBank applies the shortage rule to produced resources:
+// if the bank can't give a resource to everybody nobody gets it, the only player gets what is left
+func (game Game) limitByBank(resourcesByColor map[Color][]ResourceCard) map[Color][]ResourceCard {
+	demand := make(map[ResourceCard]int64)
+	receivers := make(map[ResourceCard]map[Color]bool)
+
+	for color, resources := range resourcesByColor {
+		for _, resource := range resources {
+			demand[resource]++
+
+			if receivers[resource] == nil {
+				receivers[resource] = make(map[Color]bool)
+			}
+
+			receivers[resource][color] = true
+		}
+	}
+
+	limitedResourcesByColor := make(map[Color][]ResourceCard, len(resourcesByColor))
+
+	for color, resources := range resourcesByColor {
+		given := make(map[ResourceCard]int64)
+
+		for _, resource := range resources {
+			if demand[resource] > game.bankResources[resource] {
+				if len(receivers[resource]) > 1 || given[resource] == game.bankResources[resource] {
+					continue
+				}
+			}
+
+			given[resource]++
+			limitedResourcesByColor[color] = append(limitedResourcesByColor[color], resource)
+		}
+	}
+
+	return limitedResourcesByColor
+}
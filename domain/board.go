@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/rannoch/catan/grid"
 )
@@ -29,6 +30,12 @@ type Board interface {
 	UpdateHex(hexCoord grid.HexCoord, hex Hex) error
 
 	HexesByNumberToken(numberToken int64) []Hex
+
+	Robber() (grid.HexCoord, bool)
+
+	MoveRobber(hexCoord grid.HexCoord) error
+
+	AddPort(pathCoord grid.PathCoord, port Port) error
 }
 
 var (
@@ -40,6 +47,14 @@ var (
 	BadPathCoordErr = errors.New("bad path coord")
 	// BadPathCoordErr is used when path in not the board
 	BadHexCoordErr = errors.New("bad hex coord")
+	// NoSettlementToUpgradeErr is used when city is placed not on the player's settlement
+	NoSettlementToUpgradeErr = errors.New("no settlement to upgrade to city")
+	// RobberMustBeMovedErr is used when robber is placed to the hex where it already is
+	RobberMustBeMovedErr = errors.New("robber must be moved to another hex")
+	// SettlementIsNotConnectedErr is used when settlement is placed away from the player's roads
+	SettlementIsNotConnectedErr = errors.New("settlement must be connected to the player's road")
+	// NoPlaceForRoadErr is used when the player has no path to build a road on
+	NoPlaceForRoadErr = errors.New("no place for a road")
 )
 
 type BoardWithOffsetCoord struct {
@@ -48,20 +63,29 @@ type BoardWithOffsetCoord struct {
 	hexes         map[grid.HexCoord]Hex
 	intersections map[grid.IntersectionCoord]Intersection
 	paths         map[grid.PathCoord]Path
+
+	robber *grid.HexCoord
 }
 
 func NewBoardWithOffsetCoord(
 	hexes map[grid.HexCoord]Hex,
 ) *BoardWithOffsetCoord {
-	boardWithOffsetCoord := &BoardWithOffsetCoord{
-		hexes: hexes,
-	}
+	boardWithOffsetCoord := &BoardWithOffsetCoord{}
 
+	// the board keeps its own hexes, the caller's map is left as it is
+	boardWithOffsetCoord.hexes = make(map[grid.HexCoord]Hex, len(hexes))
 	boardWithOffsetCoord.intersections = make(map[grid.IntersectionCoord]Intersection)
 	boardWithOffsetCoord.paths = make(map[grid.PathCoord]Path)
 
-	// calculate intersections and paths coords from hexes
-	for hexCoord := range hexes {
+	// calculate intersections and paths coords from land hexes, water only surrounds them
+	for hexCoord, hex := range hexes {
+		hex.Coord = hexCoord
+		boardWithOffsetCoord.hexes[hexCoord] = hex
+
+		if !hex.IsLand() {
+			continue
+		}
+
 		adjacentIntersectionCoords := boardWithOffsetCoord.HexAdjacentIntersections(hexCoord)
 
 		for _, intersectionCoord := range adjacentIntersectionCoords {
@@ -75,6 +99,15 @@ func NewBoardWithOffsetCoord(
 		}
 	}
 
+	// robber starts in the desert
+	for _, hex := range boardWithOffsetCoord.sortedHexes() {
+		if hex.Type == HexTypeDesert {
+			robber := hex.Coord
+			boardWithOffsetCoord.robber = &robber
+			break
+		}
+	}
+
 	return boardWithOffsetCoord
 }
 
@@ -156,8 +189,86 @@ func (board BoardWithOffsetCoord) UpdateHex(hexCoord grid.HexCoord, hex Hex) err
 	return nil
 }
 
+// HexesByNumberToken returns producing hexes with the number token ordered by coord, the hex with the robber is skipped
 func (board BoardWithOffsetCoord) HexesByNumberToken(roll int64) []Hex {
-	panic("implement me")
+	var hexes []Hex
+
+	for _, hex := range board.sortedHexes() {
+		if hex.NumberToken != NumberToken(roll) {
+			continue
+		}
+
+		if robber, exists := board.Robber(); exists && hex.Coord == robber {
+			continue
+		}
+
+		hexes = append(hexes, hex)
+	}
+
+	return hexes
+}
+
+func (board BoardWithOffsetCoord) Robber() (grid.HexCoord, bool) {
+	if board.robber == nil {
+		return grid.HexCoord{}, false
+	}
+
+	return *board.robber, true
+}
+
+func (board *BoardWithOffsetCoord) MoveRobber(hexCoord grid.HexCoord) error {
+	hex, exists := board.hexes[hexCoord]
+	if !exists || !hex.IsLand() {
+		return BadHexCoordErr
+	}
+
+	if robber, exists := board.Robber(); exists && hexCoord == robber {
+		return RobberMustBeMovedErr
+	}
+
+	board.robber = &hexCoord
+	return nil
+}
+
+// AddPort places the port on the coastal path, both path intersections get the port
+func (board *BoardWithOffsetCoord) AddPort(pathCoord grid.PathCoord, port Port) error {
+	path, exists := board.paths[pathCoord]
+	if !exists {
+		return BadPathCoordErr
+	}
+
+	intersectionCoords := board.PathAdjacentIntersections(pathCoord)
+
+	for _, intersectionCoord := range intersectionCoords {
+		if !isCoastalIntersection(board, intersectionCoord) {
+			return PortMustBeOnCoastErr
+		}
+	}
+
+	path.port = &port
+	board.paths[pathCoord] = path
+
+	for _, intersectionCoord := range intersectionCoords {
+		intersection := board.intersections[intersectionCoord]
+		intersection.port = &port
+		board.intersections[intersectionCoord] = intersection
+	}
+
+	return nil
+}
+
+func (board BoardWithOffsetCoord) sortedHexes() []Hex {
+	hexes := board.Hexes()
+
+	sort.Slice(hexes, func(i, j int) bool {
+		if hexes[i].Coord.R != hexes[j].Coord.R {
+			return hexes[i].Coord.R < hexes[j].Coord.R
+		}
+
+		return hexes[i].Coord.C < hexes[j].Coord.C
+	})
+
+	return hexes
 }
 
 // settlement, city, or knight in future
@@ -204,12 +315,56 @@ func (s Settlement) Cost() []ResourceCard {
 	return []ResourceCard{ResourceCardWood, ResourceCardBrick, ResourceCardSheep, ResourceCardWheat}
 }
 
+// canBuildSettlement checks the intersection is free and the distance rule: no buildings on adjacent intersections
+func canBuildSettlement(board Board, settlement Settlement) error {
+	intersection, exists := board.Intersection(settlement.IntersectionCoord())
+	if !exists {
+		return BadIntersectionCoordErr
+	}
+
+	if !intersection.IsEmpty() {
+		return IntersectionAlreadyHasObjectErr
+	}
+
+	// distance check
+	adjacentIntersectionsCoords := board.IntersectionAdjacentIntersections(settlement.IntersectionCoord())
+	for _, adjacentIntersectionCoord := range adjacentIntersectionsCoords {
+		adjacentIntersection, exists := board.Intersection(adjacentIntersectionCoord)
+		if !exists {
+			continue
+		}
+
+		if !adjacentIntersection.IsEmpty() {
+			return CommandIsForbiddenErr
+		}
+	}
+
+	return nil
+}
+
+// isConnectedToRoad checks the player has a road leading to the intersection
+func isConnectedToRoad(board Board, color Color, intersectionCoord grid.IntersectionCoord) bool {
+	for _, adjacentPathCoord := range board.IntersectionAdjacentPaths(intersectionCoord) {
+		adjacentPath, exists := board.Path(adjacentPathCoord)
+		if !exists || adjacentPath.IsEmpty() {
+			continue
+		}
+
+		if adjacentPath.Road().color == color {
+			return true
+		}
+	}
+
+	return false
+}
+
 type City struct {
-	color Color
+	color             Color
+	intersectionCoord grid.IntersectionCoord
 }
 
-func NewCity(color Color) City {
-	return City{color: color}
+func NewCity(color Color, intersectionCoord grid.IntersectionCoord) City {
+	return City{color: color, intersectionCoord: intersectionCoord}
 }
 
 var (
@@ -218,7 +373,7 @@ var (
 )
 
 func (c City) IntersectionCoord() grid.IntersectionCoord {
-	panic("t") // todo add coord to city
+	return c.intersectionCoord
 }
 
 func (c City) Color() Color {
@@ -270,6 +425,11 @@ type Hex struct {
 	Resource    Resource
 }
 
+// IsLand returns true for hexes where the robber and buildings can be placed
+func (hex Hex) IsLand() bool {
+	return hex.Type == HexTypeResource || hex.Type == HexTypeDesert
+}
+
 type hexType string
 
 const (
@@ -281,7 +441,7 @@ const (
 
 type Intersection struct {
 	coord    grid.IntersectionCoord
-	port     interface{} // todo port
+	port     *Port
 	building Building
 }
 
@@ -289,6 +449,10 @@ func NewIntersection(coord grid.IntersectionCoord) Intersection {
 	return Intersection{coord: coord}
 }
 
+func (intersection Intersection) Coord() grid.IntersectionCoord {
+	return intersection.coord
+}
+
 func (intersection Intersection) Building() Building {
 	return intersection.building
 }
@@ -301,11 +465,27 @@ func (intersection Intersection) IsEmpty() bool {
 	return intersection.building == nil
 }
 
+func (intersection Intersection) Port() (Port, bool) {
+	if intersection.port == nil {
+		return Port{}, false
+	}
+
+	return *intersection.port, true
+}
+
 type Path struct {
-	port interface{} // todo port
+	port *Port
 	road *Road
 }
 
+func (path Path) Port() (Port, bool) {
+	if path.port == nil {
+		return Port{}, false
+	}
+
+	return *path.port, true
+}
+
 func (path Path) Road() *Road {
 	return path.road
 }
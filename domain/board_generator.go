@@ -0,0 +1,429 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/rannoch/catan/grid"
+)
+
+// maxBoardGenerationAttempts limits regenerating the board until it is balanced
+const maxBoardGenerationAttempts = 1000
+
+// hexCorners is the number of corners the spiral of number tokens may start from
+const hexCorners = 6
+
+// RedNumbersCannotBeSeparatedErr is used when the layout has no room to place 6 and 8 apart
+var RedNumbersCannotBeSeparatedErr = errors.New("6 and 8 cannot be separated on the layout")
+
+// RandomBoardGenerator
+// shuffles resources over the layout land hexes, lays number tokens out, surrounds the island with water and places ports,
+// the same seed always generates the same board
+type RandomBoardGenerator struct {
+	layout BoardLayout
+	seed   int64
+
+	// 6 and 8 are placed on hexes not adjacent to each other
+	separateRedNumbers bool
+	// boards failing the check are generated again
+	balanceCheck func(balance BoardBalance) bool
+}
+
+func NewRandomBoardGenerator(seed int64) RandomBoardGenerator {
+	return NewRandomBoardGeneratorWithLayout(BaseBoardLayout(), seed)
+}
+
+func NewRandomBoardGeneratorWithLayout(layout BoardLayout, seed int64) RandomBoardGenerator {
+	return RandomBoardGenerator{layout: layout, seed: seed}
+}
+
+// WithSeparatedRedNumbers returns the generator never placing 6 and 8 on adjacent hexes,
+// the layout must have room for them, otherwise the generator panics with RedNumbersCannotBeSeparatedErr
+func (generator RandomBoardGenerator) WithSeparatedRedNumbers() RandomBoardGenerator {
+	generator.separateRedNumbers = true
+	return generator
+}
+
+// WithBalanceCheck returns the generator rejecting boards the check fails on,
+// the last board is returned when no balanced board is generated in maxBoardGenerationAttempts
+func (generator RandomBoardGenerator) WithBalanceCheck(balanceCheck func(balance BoardBalance) bool) RandomBoardGenerator {
+	generator.balanceCheck = balanceCheck
+	return generator
+}
+
+func (generator RandomBoardGenerator) Seed() (int64, bool) {
+	return generator.seed, true
+}
+
+func (generator RandomBoardGenerator) GenerateBoard() Board {
+	random := rand.New(rand.NewSource(generator.seed))
+
+	var board Board
+
+	for attempt := 0; attempt < maxBoardGenerationAttempts; attempt++ {
+		board = generator.generateBoard(random)
+
+		if generator.isBalanced(NewBoardBalance(board)) {
+			break
+		}
+	}
+
+	return board
+}
+
+func (generator RandomBoardGenerator) isBalanced(balance BoardBalance) bool {
+	return generator.balanceCheck == nil || generator.balanceCheck(balance)
+}
+
+func (generator RandomBoardGenerator) generateBoard(random *rand.Rand) Board {
+	hexes := generator.generateLand(random)
+
+	for _, hexCoord := range waterHexCoords(hexes) {
+		hexes[hexCoord] = Hex{Type: HexTypeWater, Resource: EmptyResource}
+	}
+
+	board := NewBoardWithOffsetCoord(hexes)
+
+	if err := generator.placePorts(board, random); err != nil {
+		panic(err)
+	}
+
+	return board
+}
+
+func (generator RandomBoardGenerator) generateLand(random *rand.Rand) map[grid.HexCoord]Hex {
+	layout := generator.layout
+
+	resources := layout.hexResources()
+	random.Shuffle(len(resources), func(i, j int) {
+		resources[i], resources[j] = resources[j], resources[i]
+	})
+
+	hexes := make(map[grid.HexCoord]Hex)
+
+	for i, hexCoord := range layout.HexCoords() {
+		if resources[i] == EmptyResource {
+			hexes[hexCoord] = Hex{Type: HexTypeDesert, Resource: EmptyResource}
+			continue
+		}
+
+		hexes[hexCoord] = Hex{Type: HexTypeResource, Resource: resources[i]}
+	}
+
+	var (
+		numberTokens []NumberToken
+		hexCoords    []grid.HexCoord
+	)
+
+	if len(layout.NumberTokenSequence) > 0 {
+		numberTokens = append(numberTokens, layout.NumberTokenSequence...)
+		hexCoords = spiralHexCoords(layout.center(), int64(len(layout.Rows)/2), random.Intn(hexCorners))
+	} else {
+		numberTokens = layout.numberTokens()
+		random.Shuffle(len(numberTokens), func(i, j int) {
+			numberTokens[i], numberTokens[j] = numberTokens[j], numberTokens[i]
+		})
+
+		hexCoords = layout.HexCoords()
+	}
+
+	for _, hexCoord := range hexCoords {
+		hex := hexes[hexCoord]
+		if hex.Type == HexTypeDesert {
+			continue
+		}
+
+		hex.NumberToken = numberTokens[0]
+		numberTokens = numberTokens[1:]
+
+		hexes[hexCoord] = hex
+	}
+
+	if generator.separateRedNumbers {
+		generator.placeRedNumbersApart(hexes, random)
+	}
+
+	return hexes
+}
+
+// placeRedNumbersApart moves 6 and 8 to random hexes not adjacent to each other,
+// tokens from these hexes take the places of 6 and 8 in the same order
+func (generator RandomBoardGenerator) placeRedNumbersApart(hexes map[grid.HexCoord]Hex, random *rand.Rand) {
+	var (
+		numberedHexCoords []grid.HexCoord
+		redNumberTokens   []NumberToken
+		otherNumberTokens []NumberToken
+	)
+
+	for _, hexCoord := range generator.layout.HexCoords() {
+		numberToken := hexes[hexCoord].NumberToken
+		if numberToken == NumberTokenEmpty {
+			continue
+		}
+
+		numberedHexCoords = append(numberedHexCoords, hexCoord)
+
+		if isRedNumber(numberToken) {
+			redNumberTokens = append(redNumberTokens, numberToken)
+		} else {
+			otherNumberTokens = append(otherNumberTokens, numberToken)
+		}
+	}
+
+	candidates := make([]grid.HexCoord, len(numberedHexCoords))
+	copy(candidates, numberedHexCoords)
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	redHexCoords, ok := nonAdjacentHexCoords(candidates, len(redNumberTokens))
+	if !ok {
+		panic(RedNumbersCannotBeSeparatedErr)
+	}
+
+	for _, hexCoord := range numberedHexCoords {
+		hex := hexes[hexCoord]
+
+		if redHexCoords[hexCoord] {
+			hex.NumberToken, redNumberTokens = redNumberTokens[0], redNumberTokens[1:]
+		} else {
+			hex.NumberToken, otherNumberTokens = otherNumberTokens[0], otherNumberTokens[1:]
+		}
+
+		hexes[hexCoord] = hex
+	}
+}
+
+// nonAdjacentHexCoords picks n candidates no two of which are adjacent, earlier candidates are preferred
+func nonAdjacentHexCoords(candidates []grid.HexCoord, n int) (map[grid.HexCoord]bool, bool) {
+	picked := make(map[grid.HexCoord]bool)
+
+	var pick func(start int) bool
+	pick = func(start int) bool {
+		if len(picked) == n {
+			return true
+		}
+
+		for i := start; i < len(candidates) && len(picked)+len(candidates)-i >= n; i++ {
+			isAdjacent := false
+			for _, adjacentHexCoord := range grid.HexNeighbours(grid.HexagonGridWithOffsetCoordsCalculator{}, candidates[i]) {
+				isAdjacent = isAdjacent || picked[adjacentHexCoord]
+			}
+
+			if isAdjacent {
+				continue
+			}
+
+			picked[candidates[i]] = true
+			if pick(i + 1) {
+				return true
+			}
+			delete(picked, candidates[i])
+		}
+
+		return false
+	}
+
+	if !pick(0) {
+		return nil, false
+	}
+
+	return picked, true
+}
+
+// placePorts spreads ports evenly along the coast, two ports never share an intersection
+func (generator RandomBoardGenerator) placePorts(board Board, random *rand.Rand) error {
+	ports := generator.layout.ports()
+	if len(ports) == 0 {
+		return nil
+	}
+
+	random.Shuffle(len(ports), func(i, j int) {
+		ports[i], ports[j] = ports[j], ports[i]
+	})
+
+	coastalPathCoords := coastalPathCoords(board)
+	offset := random.Intn(len(coastalPathCoords))
+
+	for i, port := range ports {
+		pathCoord := coastalPathCoords[(offset+i*len(coastalPathCoords)/len(ports))%len(coastalPathCoords)]
+
+		if err := board.AddPort(pathCoord, port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// center returns the middle hex of the middle row
+func (layout BoardLayout) center() grid.HexCoord {
+	middleRow := len(layout.Rows) / 2
+
+	return grid.HexCoord{
+		R: int64(middleRow),
+		C: layout.Rows[middleRow].FirstColumn + layout.Rows[middleRow].Hexes/2,
+	}
+}
+
+// ports returns all ports in a stable order
+func (layout BoardLayout) ports() []Port {
+	var ports []Port
+
+	for i := int64(0); i < layout.Ports[EmptyResource]; i++ {
+		ports = append(ports, NewGenericPort())
+	}
+
+	for _, resource := range []Resource{Ore, Wheat, Sheep, Brick, Wood} {
+		for i := int64(0); i < layout.Ports[resource]; i++ {
+			ports = append(ports, NewResourcePort(resource))
+		}
+	}
+
+	return ports
+}
+
+// spiralHexCoords goes ring by ring from the corner of the outer ring to the center
+func spiralHexCoords(center grid.HexCoord, radius int64, startCorner int) []grid.HexCoord {
+	var hexCoords []grid.HexCoord
+
+	for ring := radius; ring > 0; ring-- {
+		ringHexCoords := grid.HexRing(center, ring)
+		start := int64(startCorner) * ring
+
+		hexCoords = append(hexCoords, ringHexCoords[start:]...)
+		hexCoords = append(hexCoords, ringHexCoords[:start]...)
+	}
+
+	return append(hexCoords, center)
+}
+
+func isRedNumber(numberToken NumberToken) bool {
+	return numberToken == 6 || numberToken == 8
+}
+
+// waterHexCoords returns hexes around the island
+func waterHexCoords(hexes map[grid.HexCoord]Hex) []grid.HexCoord {
+	water := make(map[grid.HexCoord]bool)
+
+	for hexCoord := range hexes {
+		for _, adjacentHexCoord := range grid.HexNeighbours(grid.HexagonGridWithOffsetCoordsCalculator{}, hexCoord) {
+			if _, exists := hexes[adjacentHexCoord]; !exists {
+				water[adjacentHexCoord] = true
+			}
+		}
+	}
+
+	hexCoords := make([]grid.HexCoord, 0, len(water))
+	for hexCoord := range water {
+		hexCoords = append(hexCoords, hexCoord)
+	}
+
+	return hexCoords
+}
+
+// pathHexCoords returns two hexes the path lies between
+func pathHexCoords(pathCoord grid.PathCoord) []grid.HexCoord {
+	hexCoord := grid.HexCoord{R: pathCoord.R, C: pathCoord.C}
+
+	switch pathCoord.D {
+	case grid.W:
+		return []grid.HexCoord{hexCoord, {R: pathCoord.R - 1, C: pathCoord.C - 1}}
+	case grid.N:
+		return []grid.HexCoord{hexCoord, {R: pathCoord.R - 1, C: pathCoord.C}}
+	case grid.E:
+		return []grid.HexCoord{hexCoord, {R: pathCoord.R, C: pathCoord.C + 1}}
+	}
+
+	return nil
+}
+
+// coastalPathCoords returns paths between land and water going around the island
+func coastalPathCoords(board Board) []grid.PathCoord {
+	type coastalPath struct {
+		coord grid.PathCoord
+		angle float64
+	}
+
+	var (
+		coastalPaths []coastalPath
+		coordsSum    grid.HexCoord
+		landHexes    int64
+	)
+
+	// coords are summed as integers, float sums would depend on the map order
+	for _, hex := range board.Hexes() {
+		if !hex.IsLand() {
+			continue
+		}
+
+		coordsSum.R += hex.Coord.R
+		coordsSum.C += hex.Coord.C
+		landHexes++
+	}
+
+	centerX, centerY := hexCenter(coordsSum)
+	centerX /= float64(landHexes)
+	centerY /= float64(landHexes)
+
+	for _, hex := range board.Hexes() {
+		if !hex.IsLand() {
+			continue
+		}
+
+		for _, pathCoord := range board.HexAdjacentPaths(hex.Coord) {
+			isCoastal := false
+
+			for _, hexCoord := range pathHexCoords(pathCoord) {
+				if adjacentHex, exists := board.Hex(hexCoord); !exists || !adjacentHex.IsLand() {
+					isCoastal = true
+				}
+			}
+
+			if !isCoastal {
+				continue
+			}
+
+			x1, y1 := hexCenter(pathHexCoords(pathCoord)[0])
+			x2, y2 := hexCenter(pathHexCoords(pathCoord)[1])
+
+			coastalPaths = append(coastalPaths, coastalPath{
+				coord: pathCoord,
+				angle: math.Atan2((y1+y2)/2-centerY, (x1+x2)/2-centerX),
+			})
+		}
+	}
+
+	// hexes come in the map order, equal angles are ordered by coords to keep the board reproducible
+	sort.Slice(coastalPaths, func(i, j int) bool {
+		a, b := coastalPaths[i], coastalPaths[j]
+
+		if a.angle != b.angle {
+			return a.angle < b.angle
+		}
+
+		if a.coord.R != b.coord.R {
+			return a.coord.R < b.coord.R
+		}
+
+		if a.coord.C != b.coord.C {
+			return a.coord.C < b.coord.C
+		}
+
+		return a.coord.D < b.coord.D
+	})
+
+	pathCoords := make([]grid.PathCoord, 0, len(coastalPaths))
+	for _, path := range coastalPaths {
+		pathCoords = append(pathCoords, path.coord)
+	}
+
+	return pathCoords
+}
+
+// hexCenter returns the hex center on the plane, adjacent hexes are at the distance of 1
+func hexCenter(hexCoord grid.HexCoord) (float64, float64) {
+	return float64(hexCoord.C) - float64(hexCoord.R)/2, float64(hexCoord.R) * math.Sqrt(3) / 2
+}
@@ -0,0 +1,132 @@
+package domain
+
+import "github.com/rannoch/catan/grid"
+
+// BoardLayoutRow is a row of land hexes starting from the column
+type BoardLayoutRow struct {
+	FirstColumn int64
+	Hexes       int64
+}
+
+// BoardLayout
+// describes the island the board generator builds: land hexes by rows and what is placed on them
+type BoardLayout struct {
+	Rows []BoardLayoutRow
+
+	// deserts are counted as EmptyResource
+	Resources    map[Resource]int64
+	NumberTokens map[NumberToken]int64
+
+	// tokens are placed in this order along the spiral from a corner to the center skipping deserts,
+	// the island must be a hexagon, tokens are shuffled when the sequence is empty
+	NumberTokenSequence []NumberToken
+
+	// generic ports are counted as EmptyResource
+	Ports map[Resource]int64
+}
+
+// BaseBoardLayout is the 19 hexes island of the base game
+func BaseBoardLayout() BoardLayout {
+	return BoardLayout{
+		Rows: []BoardLayoutRow{
+			{FirstColumn: 0, Hexes: 3},
+			{FirstColumn: 0, Hexes: 4},
+			{FirstColumn: 0, Hexes: 5},
+			{FirstColumn: 1, Hexes: 4},
+			{FirstColumn: 2, Hexes: 3},
+		},
+		Resources: map[Resource]int64{
+			Wood:          4,
+			Sheep:         4,
+			Wheat:         4,
+			Brick:         3,
+			Ore:           3,
+			EmptyResource: 1,
+		},
+		NumberTokens: map[NumberToken]int64{
+			2: 1, 3: 2, 4: 2, 5: 2, 6: 2, 8: 2, 9: 2, 10: 2, 11: 2, 12: 1,
+		},
+		NumberTokenSequence: []NumberToken{5, 2, 6, 3, 8, 10, 9, 12, 11, 4, 8, 10, 9, 4, 5, 6, 3, 11},
+		Ports: map[Resource]int64{
+			EmptyResource: 4,
+			Wood:          1,
+			Sheep:         1,
+			Wheat:         1,
+			Brick:         1,
+			Ore:           1,
+		},
+	}
+}
+
+// ExtensionBoardLayout is the 30 hexes island of the 5-6 player extension
+func ExtensionBoardLayout() BoardLayout {
+	return BoardLayout{
+		Rows: []BoardLayoutRow{
+			{FirstColumn: 0, Hexes: 3},
+			{FirstColumn: 0, Hexes: 4},
+			{FirstColumn: 0, Hexes: 5},
+			{FirstColumn: 0, Hexes: 6},
+			{FirstColumn: 1, Hexes: 5},
+			{FirstColumn: 2, Hexes: 4},
+			{FirstColumn: 3, Hexes: 3},
+		},
+		Resources: map[Resource]int64{
+			Wood:          6,
+			Sheep:         6,
+			Wheat:         6,
+			Brick:         5,
+			Ore:           5,
+			EmptyResource: 2,
+		},
+		NumberTokens: map[NumberToken]int64{
+			2: 2, 3: 3, 4: 3, 5: 3, 6: 3, 8: 3, 9: 3, 10: 3, 11: 3, 12: 2,
+		},
+		Ports: map[Resource]int64{
+			EmptyResource: 5,
+			Wood:          1,
+			Sheep:         2,
+			Wheat:         1,
+			Brick:         1,
+			Ore:           1,
+		},
+	}
+}
+
+// HexCoords returns land hexes row by row
+func (layout BoardLayout) HexCoords() []grid.HexCoord {
+	var hexCoords []grid.HexCoord
+
+	for r, row := range layout.Rows {
+		for c := row.FirstColumn; c < row.FirstColumn+row.Hexes; c++ {
+			hexCoords = append(hexCoords, grid.HexCoord{R: int64(r), C: c})
+		}
+	}
+
+	return hexCoords
+}
+
+// hexResources returns resources of all land hexes, deserts included, in a stable order
+func (layout BoardLayout) hexResources() []Resource {
+	var resources []Resource
+
+	for _, resource := range []Resource{Ore, Wheat, Sheep, Brick, Wood, EmptyResource} {
+		for i := int64(0); i < layout.Resources[resource]; i++ {
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources
+}
+
+// numberTokens returns all number tokens in the ascending order
+func (layout BoardLayout) numberTokens() []NumberToken {
+	var numberTokens []NumberToken
+
+	for numberToken := NumberToken(2); numberToken <= 12; numberToken++ {
+		for i := int64(0); i < layout.NumberTokens[numberToken]; i++ {
+			numberTokens = append(numberTokens, numberToken)
+		}
+	}
+
+	return numberTokens
+}
@@ -0,0 +1,513 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rannoch/catan/grid"
+)
+
+// Board map
+// is the text format of the board, every line is a row of hexes, a hex is in the column of its position in the line:
+//
+//	# the board from the rules
+//	O10 S2  W9
+//	G12 B6  S4  B10
+//	G9  W11 D   W3  O8
+//	.   W8  O3  G4  S5
+//	.   .   B5  G6  S11
+//	robber: 2,2
+//	ports:
+//	0,0,N 3:1
+//	0,2,N S
+//
+// hexes are O - ore, G - wheat, S - sheep, B - brick, W - wood followed by the number token, D - desert, ~ - water,
+// . - no hex. "origin: R,C" before the rows moves the first hex from 0,0.
+// "robber: R,C" before the ports places the robber, it starts in the desert without the line.
+// Ports are placed on paths "R,C,D" where D is W, N or E, 3:1 is the generic port, 2:1 ports are named by the resource.
+// Everything after # is a comment
+
+var (
+	// UnknownHexCodeErr is used when the map has a hex not described by the format
+	UnknownHexCodeErr = errors.New("unknown hex code")
+	// BadPortErr is used when the port line can't be parsed
+	BadPortErr = errors.New("bad port")
+	// BadOriginErr is used when the origin can't be parsed or follows the rows
+	BadOriginErr = errors.New("bad origin")
+	// BadRobberErr is used when the robber can't be parsed or is placed twice
+	BadRobberErr = errors.New("bad robber")
+	// EmptyBoardMapErr is used when the map has no land hexes
+	EmptyBoardMapErr = errors.New("board map has no land hexes")
+)
+
+const (
+	boardMapPorts  = "ports:"
+	boardMapOrigin = "origin:"
+	boardMapRobber = "robber:"
+
+	boardMapNoHex       = "."
+	boardMapWater       = "~"
+	boardMapDesert      = "D"
+	boardMapGenericPort = "3:1"
+)
+
+var boardMapResources = map[string]Resource{
+	"O": Ore,
+	"G": Wheat,
+	"S": Sheep,
+	"B": Brick,
+	"W": Wood,
+}
+
+var boardMapPathDirections = map[string]grid.PathDirection{
+	"W": grid.W,
+	"N": grid.N,
+	"E": grid.E,
+}
+
+// BoardMapError points to the place in the map where parsing failed
+type BoardMapError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (err BoardMapError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", err.Line, err.Column, err.Err)
+}
+
+func (err BoardMapError) Unwrap() error {
+	return err.Err
+}
+
+// MapBoardGenerator generates the board from the map, every call returns a new board
+type MapBoardGenerator struct {
+	boardMap string
+}
+
+// NewMapBoardGenerator checks the map once, so the board is always generated
+func NewMapBoardGenerator(boardMap string) (MapBoardGenerator, error) {
+	if _, err := ParseBoardMap(boardMap); err != nil {
+		return MapBoardGenerator{}, err
+	}
+
+	return MapBoardGenerator{boardMap: boardMap}, nil
+}
+
+func (generator MapBoardGenerator) GenerateBoard() Board {
+	board, err := ParseBoardMap(generator.boardMap)
+	if err != nil {
+		panic(err)
+	}
+
+	return board
+}
+
+// boardMapField is the word of the line and its column counted from 1
+type boardMapField struct {
+	text   string
+	column int
+}
+
+func boardMapFields(line string) []boardMapField {
+	var fields []boardMapField
+
+	start := -1
+	for i, char := range line + " " {
+		if char != ' ' && char != '\t' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			fields = append(fields, boardMapField{text: line[start:i], column: start + 1})
+			start = -1
+		}
+	}
+
+	return fields
+}
+
+func ParseBoardMap(boardMap string) (*BoardWithOffsetCoord, error) {
+	hexes := make(map[grid.HexCoord]Hex)
+
+	// ports are added in the order of lines to report the first bad one
+	type boardMapPort struct {
+		pathCoord grid.PathCoord
+		port      Port
+		position  BoardMapError
+	}
+
+	// the robber is moved after the board is created
+	type boardMapRobberHex struct {
+		hexCoord grid.HexCoord
+		position BoardMapError
+	}
+
+	var (
+		ports   []boardMapPort
+		origin  grid.HexCoord
+		robber  *boardMapRobberHex
+		rows    int64
+		isPorts bool
+		hasLand bool
+	)
+
+	for i, line := range strings.Split(boardMap, "\n") {
+		lineNumber := i + 1
+
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+
+		fields := boardMapFields(strings.TrimRight(line, "\r"))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case fields[0].text == boardMapPorts && len(fields) == 1:
+			isPorts = true
+		case fields[0].text == boardMapOrigin:
+			if rows > 0 || isPorts || len(fields) != 2 {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[0].column, Err: BadOriginErr}
+			}
+
+			var ok bool
+			if origin, ok = parseBoardMapHexCoord(fields[1].text); !ok {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[1].column, Err: BadOriginErr}
+			}
+		case fields[0].text == boardMapRobber && !isPorts:
+			if robber != nil || len(fields) != 2 {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[0].column, Err: BadRobberErr}
+			}
+
+			hexCoord, ok := parseBoardMapHexCoord(fields[1].text)
+			if !ok {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[1].column, Err: BadRobberErr}
+			}
+
+			robber = &boardMapRobberHex{
+				hexCoord: hexCoord,
+				position: BoardMapError{Line: lineNumber, Column: fields[1].column},
+			}
+		case isPorts:
+			if len(fields) != 2 {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[0].column, Err: BadPortErr}
+			}
+
+			pathCoord, ok := parseBoardMapPathCoord(fields[0].text)
+			if !ok {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[0].column, Err: BadPortErr}
+			}
+
+			port, ok := parseBoardMapPort(fields[1].text)
+			if !ok {
+				return nil, BoardMapError{Line: lineNumber, Column: fields[1].column, Err: BadPortErr}
+			}
+
+			ports = append(ports, boardMapPort{
+				pathCoord: pathCoord,
+				port:      port,
+				position:  BoardMapError{Line: lineNumber, Column: fields[0].column},
+			})
+		default:
+			for c, field := range fields {
+				hex, exists, err := parseBoardMapHex(field.text)
+				if err != nil {
+					return nil, BoardMapError{Line: lineNumber, Column: field.column, Err: err}
+				}
+
+				if !exists {
+					continue
+				}
+
+				hasLand = hasLand || hex.IsLand()
+				hexes[grid.HexCoord{R: origin.R + rows, C: origin.C + int64(c)}] = hex
+			}
+
+			rows++
+		}
+	}
+
+	if !hasLand {
+		return nil, BoardMapError{Line: 1, Column: 1, Err: EmptyBoardMapErr}
+	}
+
+	board := NewBoardWithOffsetCoord(hexes)
+
+	if robber != nil {
+		// the robber already in the desert is not moved
+		if hexCoord, exists := board.Robber(); !exists || hexCoord != robber.hexCoord {
+			if err := board.MoveRobber(robber.hexCoord); err != nil {
+				robber.position.Err = err
+
+				return nil, robber.position
+			}
+		}
+	}
+
+	for _, port := range ports {
+		if err := board.AddPort(port.pathCoord, port.port); err != nil {
+			port.position.Err = err
+
+			return nil, port.position
+		}
+	}
+
+	return board, nil
+}
+
+func parseBoardMapHex(code string) (Hex, bool, error) {
+	switch code {
+	case boardMapNoHex:
+		return Hex{}, false, nil
+	case boardMapWater:
+		return WaterHex, true, nil
+	case boardMapDesert:
+		return Hex{Type: HexTypeDesert, Resource: EmptyResource}, true, nil
+	}
+
+	resource, exists := boardMapResources[code[:1]]
+	if !exists {
+		return Hex{}, false, UnknownHexCodeErr
+	}
+
+	n, err := strconv.ParseInt(code[1:], 10, 64)
+	if err != nil || n == 7 {
+		return Hex{}, false, InvalidNumberToken
+	}
+
+	numberToken, err := NewNumberToken(n)
+	if err != nil {
+		return Hex{}, false, err
+	}
+
+	return Hex{NumberToken: numberToken, Type: HexTypeResource, Resource: resource}, true, nil
+}
+
+func parseBoardMapHexCoord(text string) (grid.HexCoord, bool) {
+	parts := strings.Split(text, ",")
+	if len(parts) != 2 {
+		return grid.HexCoord{}, false
+	}
+
+	r, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return grid.HexCoord{}, false
+	}
+
+	c, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return grid.HexCoord{}, false
+	}
+
+	return grid.HexCoord{R: r, C: c}, true
+}
+
+func parseBoardMapPathCoord(text string) (grid.PathCoord, bool) {
+	separator := strings.LastIndex(text, ",")
+	if separator < 0 {
+		return grid.PathCoord{}, false
+	}
+
+	hexCoord, ok := parseBoardMapHexCoord(text[:separator])
+	if !ok {
+		return grid.PathCoord{}, false
+	}
+
+	direction, ok := boardMapPathDirections[text[separator+1:]]
+	if !ok {
+		return grid.PathCoord{}, false
+	}
+
+	return grid.PathCoord{R: hexCoord.R, C: hexCoord.C, D: direction}, true
+}
+
+func parseBoardMapPort(code string) (Port, bool) {
+	if code == boardMapGenericPort {
+		return NewGenericPort(), true
+	}
+
+	resource, exists := boardMapResources[code]
+	if !exists {
+		return Port{}, false
+	}
+
+	return NewResourcePort(resource), true
+}
+
+// WriteBoardMap writes the board in the map format, ParseBoardMap reads it back to the same board
+func WriteBoardMap(board Board) string {
+	hexes := board.Hexes()
+	if len(hexes) == 0 {
+		return ""
+	}
+
+	minCoord, maxCoord := hexes[0].Coord, hexes[0].Coord
+	codes := make(map[grid.HexCoord]string)
+
+	for _, hex := range hexes {
+		minCoord.R, maxCoord.R = minInt64(minCoord.R, hex.Coord.R), maxInt64(maxCoord.R, hex.Coord.R)
+		minCoord.C, maxCoord.C = minInt64(minCoord.C, hex.Coord.C), maxInt64(maxCoord.C, hex.Coord.C)
+
+		codes[hex.Coord] = boardMapHexCode(hex)
+	}
+
+	var builder strings.Builder
+
+	if minCoord != (grid.HexCoord{}) {
+		fmt.Fprintf(&builder, "%s %d,%d\n", boardMapOrigin, minCoord.R, minCoord.C)
+	}
+
+	for r := minCoord.R; r <= maxCoord.R; r++ {
+		var row []string
+
+		for c := minCoord.C; c <= maxCoord.C; c++ {
+			code, exists := codes[grid.HexCoord{R: r, C: c}]
+			if !exists {
+				code = boardMapNoHex
+			}
+
+			row = append(row, fmt.Sprintf("%-3s", code))
+		}
+
+		line := strings.TrimRight(strings.Join(row, " "), " .")
+		if line == "" {
+			// the row without hexes still moves next rows down
+			line = boardMapNoHex
+		}
+
+		builder.WriteString(line + "\n")
+	}
+
+	if robber, exists := board.Robber(); exists {
+		fmt.Fprintf(&builder, "%s %d,%d\n", boardMapRobber, robber.R, robber.C)
+	}
+
+	pathCoords := boardPortPathCoords(board)
+	if len(pathCoords) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString(boardMapPorts + "\n")
+
+	for _, pathCoord := range pathCoords {
+		path, _ := board.Path(pathCoord)
+		port, _ := path.Port()
+
+		fmt.Fprintf(&builder, "%d,%d,%s %s\n", pathCoord.R, pathCoord.C, boardMapPathDirectionCode(pathCoord.D), boardMapPortCode(port))
+	}
+
+	return builder.String()
+}
+
+func boardMapHexCode(hex Hex) string {
+	switch hex.Type {
+	case HexTypeWater:
+		return boardMapWater
+	case HexTypeDesert:
+		return boardMapDesert
+	case HexTypeResource:
+		for code, resource := range boardMapResources {
+			if resource == hex.Resource {
+				return fmt.Sprintf("%s%d", code, hex.NumberToken)
+			}
+		}
+	}
+
+	return boardMapNoHex
+}
+
+func boardMapPathDirectionCode(direction grid.PathDirection) string {
+	for code, pathDirection := range boardMapPathDirections {
+		if pathDirection == direction {
+			return code
+		}
+	}
+
+	return ""
+}
+
+func boardMapPortCode(port Port) string {
+	if port.IsGeneric() {
+		return boardMapGenericPort
+	}
+
+	for code, resource := range boardMapResources {
+		if resource == port.Resource() {
+			return code
+		}
+	}
+
+	return ""
+}
+
+// boardPortPathCoords returns paths with ports sorted by coords
+func boardPortPathCoords(board Board) []grid.PathCoord {
+	var pathCoords []grid.PathCoord
+
+	for _, pathCoord := range boardPathCoords(board) {
+		path, _ := board.Path(pathCoord)
+
+		if _, hasPort := path.Port(); hasPort {
+			pathCoords = append(pathCoords, pathCoord)
+		}
+	}
+
+	return pathCoords
+}
+
+// boardPathCoords returns all paths of the board sorted by coords
+func boardPathCoords(board Board) []grid.PathCoord {
+	var pathCoords []grid.PathCoord
+	visited := make(map[grid.PathCoord]bool)
+
+	for _, hex := range board.Hexes() {
+		for _, pathCoord := range board.HexAdjacentPaths(hex.Coord) {
+			if visited[pathCoord] {
+				continue
+			}
+			visited[pathCoord] = true
+
+			if _, exists := board.Path(pathCoord); exists {
+				pathCoords = append(pathCoords, pathCoord)
+			}
+		}
+	}
+
+	sort.Slice(pathCoords, func(i, j int) bool {
+		if pathCoords[i].R != pathCoords[j].R {
+			return pathCoords[i].R < pathCoords[j].R
+		}
+
+		if pathCoords[i].C != pathCoords[j].C {
+			return pathCoords[i].C < pathCoords[j].C
+		}
+
+		return pathCoords[i].D < pathCoords[j].D
+	})
+
+	return pathCoords
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/rannoch/catan/grid"
+)
+
+// RenderBoard
+// draws the board in the ASCII style of the grid package, columns go up to the right:
+//
+//	          _ _
+//	        /     \
+//	   _ _ /  S2   \
+//	 /     \       /
+//	/  O10  \ _ _ /
+//	\       /     \
+//	 \ _ _ /  W9   \
+//	       \       /
+//	        \ _ _ /
+//
+// hexes are named as in the board map, ROB is the robber, harbors are written in the water across their path,
+// roads and settlements are drawn with the lowercase color letter, cities with the uppercase one
+func RenderBoard(board Board) string {
+	hexes := board.Hexes()
+	if len(hexes) == 0 {
+		return ""
+	}
+
+	var hexCoords []grid.HexCoord
+	for _, hex := range hexes {
+		hexCoords = append(hexCoords, hex.Coord)
+	}
+
+	portPathCoords := boardPortPathCoords(board)
+	for _, pathCoord := range portPathCoords {
+		hexCoords = append(hexCoords, pathHexCoords(pathCoord)...)
+	}
+
+	canvas := newBoardCanvas(hexCoords)
+
+	for _, hex := range hexes {
+		canvas.drawHex(hex)
+	}
+
+	if robber, exists := board.Robber(); exists {
+		x, y := canvas.hexOrigin(robber)
+		canvas.writeCentered(x+2, y+1, 5, "ROB")
+	}
+
+	// the second harbor in the same water hex is written above the first one
+	harbors := make(map[grid.HexCoord]bool)
+
+	for _, pathCoord := range portPathCoords {
+		path, _ := board.Path(pathCoord)
+		port, _ := path.Port()
+
+		for _, hexCoord := range pathHexCoords(pathCoord) {
+			if hex, exists := board.Hex(hexCoord); exists && hex.IsLand() {
+				continue
+			}
+
+			x, y := canvas.hexOrigin(hexCoord)
+			if harbors[hexCoord] {
+				canvas.writeCentered(x+2, y+1, 5, renderPort(port))
+			} else {
+				canvas.writeCentered(x+1, y+3, 7, renderPort(port))
+			}
+
+			harbors[hexCoord] = true
+		}
+	}
+
+	for _, pathCoord := range boardPathCoords(board) {
+		path, _ := board.Path(pathCoord)
+		if path.IsEmpty() {
+			continue
+		}
+
+		canvas.drawRoad(pathCoord, colorLetter(path.Road().color))
+	}
+
+	for _, intersection := range board.Intersections() {
+		if intersection.IsEmpty() {
+			continue
+		}
+
+		letter := colorLetter(intersection.Building().Color())
+		if _, isCity := intersection.Building().(City); isCity {
+			letter = strings.ToUpper(letter)
+		}
+
+		canvas.drawIntersection(intersection.Coord(), letter)
+	}
+
+	return canvas.String()
+}
+
+// renderColorLetters are unique letters of colors, black is k as in CMYK
+var renderColorLetters = map[Color]string{
+	Red:    "r",
+	Blue:   "b",
+	White:  "w",
+	Green:  "g",
+	Yellow: "y",
+	Orange: "o",
+	Black:  "k",
+}
+
+func colorLetter(color Color) string {
+	if letter, exists := renderColorLetters[color]; exists {
+		return letter
+	}
+
+	return "?"
+}
+
+func renderPort(port Port) string {
+	if port.IsGeneric() {
+		return boardMapGenericPort
+	}
+
+	return "2:1 " + boardMapPortCode(port)
+}
+
+// boardCanvas
+// is the grid of characters, the hex is 9 characters wide and 5 lines high,
+// the next column is 7 characters right and 2 lines up, the next row is 4 lines down
+type boardCanvas struct {
+	lines [][]byte
+
+	// moves the top left hex corner to the canvas origin
+	offsetX int64
+	offsetY int64
+}
+
+func newBoardCanvas(hexCoords []grid.HexCoord) *boardCanvas {
+	canvas := &boardCanvas{}
+
+	minX, minY := boardCanvasX(hexCoords[0]), boardCanvasY(hexCoords[0])
+	maxX, maxY := minX, minY
+
+	for _, hexCoord := range hexCoords {
+		x, y := boardCanvasX(hexCoord), boardCanvasY(hexCoord)
+
+		minX, maxX = minInt64(minX, x), maxInt64(maxX, x)
+		minY, maxY = minInt64(minY, y), maxInt64(maxY, y)
+	}
+
+	// left intersections are drawn one character before the hex
+	canvas.offsetX = 1 - minX
+	canvas.offsetY = -minY
+
+	width := maxX - minX + 11
+	height := maxY - minY + 5
+
+	for i := int64(0); i < height; i++ {
+		canvas.lines = append(canvas.lines, []byte(strings.Repeat(" ", int(width))))
+	}
+
+	return canvas
+}
+
+func boardCanvasX(hexCoord grid.HexCoord) int64 {
+	return 7 * hexCoord.C
+}
+
+func boardCanvasY(hexCoord grid.HexCoord) int64 {
+	return 2 * (2*hexCoord.R - hexCoord.C)
+}
+
+// hexOrigin returns the top left corner of the hex
+func (canvas *boardCanvas) hexOrigin(hexCoord grid.HexCoord) (int64, int64) {
+	return boardCanvasX(hexCoord) + canvas.offsetX, boardCanvasY(hexCoord) + canvas.offsetY
+}
+
+func (canvas *boardCanvas) write(x, y int64, text string) {
+	if y < 0 || y >= int64(len(canvas.lines)) {
+		return
+	}
+
+	for i := range text {
+		if x+int64(i) >= 0 && x+int64(i) < int64(len(canvas.lines[y])) {
+			canvas.lines[y][x+int64(i)] = text[i]
+		}
+	}
+}
+
+func (canvas *boardCanvas) writeCentered(x, y, width int64, text string) {
+	canvas.write(x+(width-int64(len(text)))/2, y, text)
+}
+
+func (canvas *boardCanvas) drawHex(hex Hex) {
+	x, y := canvas.hexOrigin(hex.Coord)
+
+	canvas.write(x+3, y, "_ _")
+	canvas.write(x+1, y+1, "/")
+	canvas.write(x+7, y+1, "\\")
+	canvas.write(x, y+2, "/")
+	canvas.write(x+8, y+2, "\\")
+	canvas.write(x, y+3, "\\")
+	canvas.write(x+8, y+3, "/")
+	canvas.write(x+1, y+4, "\\")
+	canvas.write(x+3, y+4, "_ _")
+	canvas.write(x+7, y+4, "/")
+
+	if hex.Type == HexTypeWater {
+		canvas.writeCentered(x+2, y+2, 5, "~~~")
+		return
+	}
+
+	canvas.writeCentered(x+2, y+2, 5, boardMapHexCode(hex))
+}
+
+func (canvas *boardCanvas) drawRoad(pathCoord grid.PathCoord, letter string) {
+	x, y := canvas.hexOrigin(grid.HexCoord{R: pathCoord.R, C: pathCoord.C})
+
+	switch pathCoord.D {
+	case grid.N:
+		canvas.write(x+3, y, strings.Repeat(letter, 3))
+	case grid.W:
+		canvas.write(x+1, y+1, letter)
+		canvas.write(x, y+2, letter)
+	case grid.E:
+		canvas.write(x+7, y+1, letter)
+		canvas.write(x+8, y+2, letter)
+	}
+}
+
+func (canvas *boardCanvas) drawIntersection(intersectionCoord grid.IntersectionCoord, letter string) {
+	x, y := canvas.hexOrigin(grid.HexCoord{R: intersectionCoord.R, C: intersectionCoord.C})
+
+	if intersectionCoord.D == grid.L {
+		canvas.write(x-1, y+2, letter)
+	} else {
+		canvas.write(x+9, y+2, letter)
+	}
+}
+
+func (canvas *boardCanvas) String() string {
+	lines := make([]string, 0, len(canvas.lines))
+
+	for _, line := range canvas.lines {
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+
+	// harbors off the board leave empty lines
+	return strings.Trim(strings.Join(lines, "\n"), "\n") + "\n"
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/rannoch/catan/grid"
+)
+
+var (
+	// HexWithoutNumberTokenErr is used when the resource hex has no valid number token
+	HexWithoutNumberTokenErr = errors.New("resource hex must have a number token")
+	// DesertWithNumberTokenErr is used when the desert has a number token
+	DesertWithNumberTokenErr = errors.New("desert cannot have a number token")
+	// BadNumberTokensErr is used when number tokens on the board differ from the layout
+	BadNumberTokensErr = errors.New("number tokens do not match the layout")
+	// BadResourcesErr is used when resource hexes on the board differ from the layout
+	BadResourcesErr = errors.New("resources do not match the layout")
+	// BoardIsNotConnectedErr is used when the island is split into parts
+	BoardIsNotConnectedErr = errors.New("land hexes are not connected")
+)
+
+// ValidateBoard checks the board is legal for the layout
+func ValidateBoard(board Board, layout BoardLayout) error {
+	resources := make(map[Resource]int64)
+	numberTokens := make(map[NumberToken]int64)
+
+	for _, hex := range board.Hexes() {
+		switch hex.Type {
+		case HexTypeDesert:
+			if hex.NumberToken != NumberTokenEmpty {
+				return DesertWithNumberTokenErr
+			}
+
+			resources[EmptyResource]++
+		case HexTypeResource:
+			if _, err := NewNumberToken(int64(hex.NumberToken)); err != nil || hex.NumberToken == 7 {
+				return HexWithoutNumberTokenErr
+			}
+
+			resources[hex.Resource]++
+			numberTokens[hex.NumberToken]++
+		}
+	}
+
+	if !equalCounts(resources, layout.Resources) {
+		return BadResourcesErr
+	}
+
+	if !equalNumberTokenCounts(numberTokens, layout.NumberTokens) {
+		return BadNumberTokensErr
+	}
+
+	for _, pathCoord := range boardPortPathCoords(board) {
+		for _, intersectionCoord := range board.PathAdjacentIntersections(pathCoord) {
+			if !isCoastalIntersection(board, intersectionCoord) {
+				return PortMustBeOnCoastErr
+			}
+		}
+	}
+
+	if !isBoardConnected(board) {
+		return BoardIsNotConnectedErr
+	}
+
+	return nil
+}
+
+func equalCounts(a, b map[Resource]int64) bool {
+	for resource, count := range a {
+		if b[resource] != count {
+			return false
+		}
+	}
+
+	for resource, count := range b {
+		if a[resource] != count {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalNumberTokenCounts(a, b map[NumberToken]int64) bool {
+	for numberToken, count := range a {
+		if b[numberToken] != count {
+			return false
+		}
+	}
+
+	for numberToken, count := range b {
+		if a[numberToken] != count {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isBoardConnected walks land hexes from any of them, every land hex must be reached
+func isBoardConnected(board Board) bool {
+	var (
+		queue     []grid.HexCoord
+		landHexes int
+	)
+
+	for _, hex := range board.Hexes() {
+		if !hex.IsLand() {
+			continue
+		}
+
+		if len(queue) == 0 {
+			queue = append(queue, hex.Coord)
+		}
+
+		landHexes++
+	}
+
+	if landHexes == 0 {
+		return false
+	}
+
+	visited := map[grid.HexCoord]bool{queue[0]: true}
+
+	for len(queue) > 0 {
+		hexCoord := queue[0]
+		queue = queue[1:]
+
+		for _, adjacentHexCoord := range grid.HexNeighbours(board, hexCoord) {
+			if visited[adjacentHexCoord] {
+				continue
+			}
+
+			if hex, exists := board.Hex(adjacentHexCoord); !exists || !hex.IsLand() {
+				continue
+			}
+
+			visited[adjacentHexCoord] = true
+			queue = append(queue, adjacentHexCoord)
+		}
+	}
+
+	return len(visited) == landHexes
+}
+
+// BoardBalance
+// shows how fair the board is, generators use it to reject lopsided boards
+type BoardBalance struct {
+	// how often every resource is produced, pips are dice combinations rolling the token
+	ResourcePips map[Resource]int64
+	// how often a building on the intersection produces
+	IntersectionPips map[grid.IntersectionCoord]int64
+
+	// pairs of adjacent hexes with 6 or 8
+	AdjacentRedNumbers int64
+	// pairs of adjacent hexes with the same resource
+	ResourceClustering int64
+}
+
+func NewBoardBalance(board Board) BoardBalance {
+	balance := BoardBalance{
+		ResourcePips:     make(map[Resource]int64),
+		IntersectionPips: make(map[grid.IntersectionCoord]int64),
+	}
+
+	for _, hex := range board.Hexes() {
+		if hex.Type != HexTypeResource {
+			continue
+		}
+
+		balance.ResourcePips[hex.Resource] += hex.NumberToken.Pips()
+
+		for _, adjacentHexCoord := range grid.HexNeighbours(board, hex.Coord) {
+			adjacentHex, exists := board.Hex(adjacentHexCoord)
+			if !exists || adjacentHex.Type != HexTypeResource {
+				continue
+			}
+
+			// every pair is met from both hexes
+			if !isHexCoordLess(hex.Coord, adjacentHexCoord) {
+				continue
+			}
+
+			if isRedNumber(hex.NumberToken) && isRedNumber(adjacentHex.NumberToken) {
+				balance.AdjacentRedNumbers++
+			}
+
+			if hex.Resource == adjacentHex.Resource {
+				balance.ResourceClustering++
+			}
+		}
+	}
+
+	for _, intersection := range board.Intersections() {
+		var pips int64
+
+		for _, hexCoord := range board.IntersectionAdjacentHexes(intersection.Coord()) {
+			if hex, exists := board.Hex(hexCoord); exists {
+				pips += hex.NumberToken.Pips()
+			}
+		}
+
+		balance.IntersectionPips[intersection.Coord()] = pips
+	}
+
+	return balance
+}
+
+// ResourcePipsSpread returns the difference between the most and the least produced resources
+func (balance BoardBalance) ResourcePipsSpread() int64 {
+	var minPips, maxPips int64 = -1, 0
+
+	for _, resource := range []Resource{Ore, Wheat, Sheep, Brick, Wood} {
+		pips := balance.ResourcePips[resource]
+
+		if minPips < 0 || pips < minPips {
+			minPips = pips
+		}
+
+		if pips > maxPips {
+			maxPips = pips
+		}
+	}
+
+	return maxPips - minPips
+}
+
+// MaxIntersectionPips returns pips of the best intersection
+func (balance BoardBalance) MaxIntersectionPips() int64 {
+	var maxPips int64
+
+	for _, pips := range balance.IntersectionPips {
+		if pips > maxPips {
+			maxPips = pips
+		}
+	}
+
+	return maxPips
+}
+
+func isHexCoordLess(a, b grid.HexCoord) bool {
+	if a.R != b.R {
+		return a.R < b.R
+	}
+
+	return a.C < b.C
+}
@@ -0,0 +1,151 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Random board generator", func() {
+	var board domain.Board
+
+	BeforeEach(func() {
+		board = domain.NewRandomBoardGenerator(42).GenerateBoard()
+	})
+
+	It("should generate the same board with the same seed", func() {
+		Expect(domain.NewRandomBoardGenerator(42).GenerateBoard()).To(Equal(board))
+		Expect(domain.NewRandomBoardGenerator(43).GenerateBoard()).NotTo(Equal(board))
+	})
+
+	It("should have the standard tile distribution", func() {
+		resources := make(map[domain.Resource]int)
+		numberTokens := make(map[domain.NumberToken]int)
+
+		for _, hex := range board.Hexes() {
+			if !hex.IsLand() {
+				continue
+			}
+
+			resources[hex.Resource]++
+			numberTokens[hex.NumberToken]++
+		}
+
+		Expect(resources).To(Equal(map[domain.Resource]int{
+			domain.Wood:          4,
+			domain.Sheep:         4,
+			domain.Wheat:         4,
+			domain.Brick:         3,
+			domain.Ore:           3,
+			domain.EmptyResource: 1,
+		}))
+
+		Expect(numberTokens).To(Equal(map[domain.NumberToken]int{
+			domain.NumberTokenEmpty: 1, 2: 1, 3: 2, 4: 2, 5: 2, 6: 2, 8: 2, 9: 2, 10: 2, 11: 2, 12: 1,
+		}))
+	})
+
+	It("robber should start in the desert", func() {
+		robber, exists := board.Robber()
+		Expect(exists).To(BeTrue())
+
+		hex, _ := board.Hex(robber)
+		Expect(hex.Type).To(Equal(domain.HexTypeDesert))
+	})
+
+	It("island should be surrounded by water", func() {
+		for _, hex := range board.Hexes() {
+			if !hex.IsLand() {
+				Expect(hex.Type).To(Equal(domain.HexTypeWater))
+				continue
+			}
+
+			for _, adjacentHexCoord := range grid.HexNeighbours(grid.HexagonGridWithOffsetCoordsCalculator{}, hex.Coord) {
+				_, exists := board.Hex(adjacentHexCoord)
+				Expect(exists).To(BeTrue())
+			}
+		}
+	})
+
+	It("should have nine ports not sharing intersections", func() {
+		genericPorts, resourcePorts := 0, make(map[domain.Resource]int)
+
+		for _, path := range board.Paths() {
+			port, exists := path.Port()
+			if !exists {
+				continue
+			}
+
+			if port.IsGeneric() {
+				genericPorts++
+				continue
+			}
+
+			resourcePorts[port.Resource()]++
+		}
+
+		Expect(genericPorts).To(Equal(4))
+		Expect(resourcePorts).To(Equal(map[domain.Resource]int{
+			domain.Wood: 1, domain.Sheep: 1, domain.Wheat: 1, domain.Brick: 1, domain.Ore: 1,
+		}))
+
+		intersectionsWithPorts := 0
+		for _, intersection := range board.Intersections() {
+			if _, exists := intersection.Port(); exists {
+				intersectionsWithPorts++
+			}
+		}
+
+		Expect(intersectionsWithPorts).To(Equal(18))
+	})
+
+	It("6 and 8 should not be adjacent when separated", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			expectRedNumbersApart(domain.NewRandomBoardGenerator(seed).WithSeparatedRedNumbers().GenerateBoard())
+		}
+	})
+
+	It("6 and 8 should not be adjacent when no board is balanced", func() {
+		// the flower of 7 hexes has room for 3 red numbers on every other hex of the ring only
+		layout := flowerBoardLayout(map[domain.NumberToken]int64{6: 2, 8: 1, 4: 2, 5: 2})
+		unbalanced := func(domain.BoardBalance) bool { return false }
+
+		for seed := int64(0); seed < 5; seed++ {
+			generator := domain.NewRandomBoardGeneratorWithLayout(layout, seed).WithSeparatedRedNumbers().WithBalanceCheck(unbalanced)
+			expectRedNumbersApart(generator.GenerateBoard())
+		}
+	})
+
+	It("generator should panic when 6 and 8 have no room", func() {
+		generator := domain.NewRandomBoardGeneratorWithLayout(flowerBoardLayout(map[domain.NumberToken]int64{6: 2, 8: 2, 4: 2, 5: 1}), 1).WithSeparatedRedNumbers()
+
+		Expect(func() { generator.GenerateBoard() }).To(PanicWith(domain.RedNumbersCannotBeSeparatedErr))
+	})
+})
+
+func expectRedNumbersApart(board domain.Board) {
+	for _, hex := range board.Hexes() {
+		if hex.NumberToken != 6 && hex.NumberToken != 8 {
+			continue
+		}
+
+		for _, adjacentHexCoord := range grid.HexNeighbours(grid.HexagonGridWithOffsetCoordsCalculator{}, hex.Coord) {
+			adjacentHex, _ := board.Hex(adjacentHexCoord)
+			ExpectWithOffset(1, adjacentHex.NumberToken).NotTo(BeElementOf(domain.NumberToken(6), domain.NumberToken(8)))
+		}
+	}
+}
+
+// flowerBoardLayout is the hex with its ring of 6 hexes and no desert
+func flowerBoardLayout(numberTokens map[domain.NumberToken]int64) domain.BoardLayout {
+	return domain.BoardLayout{
+		Rows: []domain.BoardLayoutRow{
+			{FirstColumn: 0, Hexes: 2},
+			{FirstColumn: 0, Hexes: 3},
+			{FirstColumn: 1, Hexes: 2},
+		},
+		Resources:    map[domain.Resource]int64{domain.Ore: 7},
+		NumberTokens: numberTokens,
+	}
+}
@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/domain/games/catan_rule_example"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Board map", func() {
+	It("should be parsed", func() {
+		board, err := domain.ParseBoardMap(`
+# two hexes and the water
+~   ~
+O10 D   ~
+ports:
+1,0,W 3:1
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		hex, _ := board.Hex(grid.HexCoord{R: 1, C: 0})
+		Expect(hex).To(Equal(domain.Hex{
+			Coord:       grid.HexCoord{R: 1, C: 0},
+			NumberToken: 10,
+			Type:        domain.HexTypeResource,
+			Resource:    domain.Ore,
+		}))
+
+		hex, _ = board.Hex(grid.HexCoord{R: 1, C: 1})
+		Expect(hex.Type).To(Equal(domain.HexTypeDesert))
+
+		hex, _ = board.Hex(grid.HexCoord{R: 0, C: 1})
+		Expect(hex.Type).To(Equal(domain.HexTypeWater))
+
+		path, _ := board.Path(grid.PathCoord{R: 1, C: 0, D: grid.W})
+		port, exists := path.Port()
+		Expect(exists).To(BeTrue())
+		Expect(port.IsGeneric()).To(BeTrue())
+	})
+
+	DescribeTable("errors should point to the line and the column",
+		func(boardMap string, line, column int, err error) {
+			_, parseErr := domain.ParseBoardMap(boardMap)
+
+			var boardMapErr domain.BoardMapError
+			Expect(errors.As(parseErr, &boardMapErr)).To(BeTrue())
+			Expect(boardMapErr.Line).To(Equal(line))
+			Expect(boardMapErr.Column).To(Equal(column))
+			Expect(errors.Is(parseErr, err)).To(BeTrue())
+		},
+		Entry("unknown hex", "O10 S2\nO10  X4", 2, 6, domain.UnknownHexCodeErr),
+		Entry("bad number token", "O10 S7", 1, 5, domain.InvalidNumberToken),
+		Entry("bad port", "O10\nports:\n1,0,W Q", 3, 7, domain.BadPortErr),
+		Entry("port off the board", "O10\nports:\n  7,7,N 3:1", 3, 3, domain.BadPathCoordErr),
+		Entry("origin after rows", "O10\norigin: 1,1", 2, 1, domain.BadOriginErr),
+		Entry("no land", "~ ~", 1, 1, domain.EmptyBoardMapErr),
+		Entry("bad robber", "O10\nrobber: 0", 2, 9, domain.BadRobberErr),
+		Entry("robber on the water", "O10 ~\nrobber: 0,1", 2, 9, domain.BadHexCoordErr),
+	)
+
+	It("robber should be placed", func() {
+		board, err := domain.ParseBoardMap("O10 D\nrobber: 0,0")
+		Expect(err).NotTo(HaveOccurred())
+
+		robber, exists := board.Robber()
+		Expect(exists).To(BeTrue())
+		Expect(robber).To(Equal(grid.HexCoord{R: 0, C: 0}))
+	})
+
+	It("written board should be read back", func() {
+		movedRobberBoard := ruleExampleBoard()
+		Expect(movedRobberBoard.MoveRobber(grid.HexCoord{R: 1, C: 1})).To(Succeed())
+		Expect(domain.WriteBoardMap(movedRobberBoard)).To(ContainSubstring("robber: 1,1\n"))
+		Expect(domain.WriteBoardMap(ruleExampleBoard())).NotTo(ContainSubstring("robber: 1,1\n"))
+
+		for _, board := range []domain.Board{
+			ruleExampleBoard(),
+			domain.NewRandomBoardGenerator(3).GenerateBoard(),
+			movedRobberBoard,
+		} {
+			boardMap := domain.WriteBoardMap(board)
+
+			readBoard, err := domain.ParseBoardMap(boardMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readBoard).To(Equal(board))
+			Expect(domain.WriteBoardMap(readBoard)).To(Equal(boardMap))
+		}
+	})
+
+	It("generator should reject bad maps", func() {
+		_, err := domain.NewMapBoardGenerator("O10 X")
+		Expect(err).To(HaveOccurred())
+
+		generator, err := domain.NewMapBoardGenerator("O10 S2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generator.GenerateBoard().Hexes()).To(HaveLen(2))
+	})
+})
+
+// ruleExampleBoard returns the board of the rule example before buildings are placed
+func ruleExampleBoard() domain.Board {
+	for _, event := range catan_rule_example.Events() {
+		if boardGenerated, ok := event.(domain.BoardGeneratedEvent); ok {
+			return boardGenerated.NewBoard
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan board render", func() {
+	It("should draw hexes with number tokens", func() {
+		board, err := domain.ParseBoardMap("O10 S2\n.   W9")
+		Expect(err).To(BeNil())
+
+		Expect(domain.RenderBoard(board)).To(Equal(strings.Join([]string{
+			"           _ _",
+			"         /     \\",
+			"    _ _ /  S2   \\",
+			"  /     \\       /",
+			" /  O10  \\ _ _ /",
+			" \\       /     \\",
+			"  \\ _ _ /  W9   \\",
+			"        \\       /",
+			"         \\ _ _ /",
+		}, "\n") + "\n"))
+	})
+
+	It("should draw the robber and harbors of the rule example", func() {
+		rendered := domain.RenderBoard(ruleExampleBoard())
+
+		Expect(rendered).To(ContainSubstring("ROB"))
+		Expect(rendered).To(ContainSubstring("3:1"))
+		Expect(rendered).To(ContainSubstring("2:1 O"))
+	})
+
+	It("should draw roads and buildings by color", func() {
+		// blue has the settlement at the top left corner with the road along the top side,
+		// the city at the bottom right corner with the road along the bottom side
+		game := replayIsland(domain.DiceRollerSelected{DiceRoller: &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}})
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+
+		city := domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 1, C: 1, D: grid.L})
+		pickResources(game, domain.Blue, city.Cost()...)
+		Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Succeed())
+
+		Expect(domain.RenderBoard(game.Board())).To(Equal(strings.Join([]string{
+			"   bbbb",
+			"  /     \\",
+			" /  O10  \\",
+			" \\       /",
+			"  \\ bbbB/",
+		}, "\n") + "\n"))
+	})
+
+	It("should draw every color with its own letter", func() {
+		rendered := domain.RenderBoard(replayRuleExample().Board())
+
+		Expect(rendered).To(ContainSubstring("rrr"))
+		Expect(rendered).NotTo(ContainSubstring("?"))
+	})
+
+	It("should render an empty board as an empty string", func() {
+		Expect(domain.RenderBoard(domain.NewBoardWithOffsetCoord(nil))).To(BeEmpty())
+	})
+})
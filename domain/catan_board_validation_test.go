@@ -0,0 +1,121 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Board validation", func() {
+	It("generated boards should be valid", func() {
+		for seed := int64(0); seed < 10; seed++ {
+			Expect(domain.ValidateBoard(domain.NewRandomBoardGenerator(seed).GenerateBoard(), domain.BaseBoardLayout())).To(Succeed())
+		}
+
+		extensionBoard := domain.NewRandomBoardGeneratorWithLayout(domain.ExtensionBoardLayout(), 1).GenerateBoard()
+		Expect(domain.ValidateBoard(extensionBoard, domain.ExtensionBoardLayout())).To(Succeed())
+	})
+
+	It("rule example board should be valid", func() {
+		Expect(domain.ValidateBoard(ruleExampleBoard(), domain.BaseBoardLayout())).To(Succeed())
+	})
+
+	It("board should match the layout", func() {
+		Expect(domain.ValidateBoard(ruleExampleBoard(), domain.ExtensionBoardLayout())).To(Equal(domain.BadResourcesErr))
+
+		layout := domain.BaseBoardLayout()
+		layout.NumberTokens = map[domain.NumberToken]int64{
+			2: 1, 3: 2, 4: 2, 5: 2, 6: 3, 8: 1, 9: 2, 10: 2, 11: 2, 12: 1,
+		}
+		Expect(domain.ValidateBoard(ruleExampleBoard(), layout)).To(Equal(domain.BadNumberTokensErr))
+	})
+
+	It("only desert should have no number token", func() {
+		layout := domain.BoardLayout{
+			Resources:    map[domain.Resource]int64{domain.Ore: 1, domain.EmptyResource: 1},
+			NumberTokens: map[domain.NumberToken]int64{10: 1},
+		}
+
+		board := domain.NewBoardWithOffsetCoord(map[grid.HexCoord]domain.Hex{
+			{R: 0, C: 0}: {Type: domain.HexTypeResource, Resource: domain.Ore},
+			{R: 0, C: 1}: {Type: domain.HexTypeDesert, Resource: domain.EmptyResource},
+		})
+		Expect(domain.ValidateBoard(board, layout)).To(Equal(domain.HexWithoutNumberTokenErr))
+
+		board = domain.NewBoardWithOffsetCoord(map[grid.HexCoord]domain.Hex{
+			{R: 0, C: 0}: {NumberToken: 10, Type: domain.HexTypeResource, Resource: domain.Ore},
+			{R: 0, C: 1}: {NumberToken: 4, Type: domain.HexTypeDesert, Resource: domain.EmptyResource},
+		})
+		Expect(domain.ValidateBoard(board, layout)).To(Equal(domain.DesertWithNumberTokenErr))
+	})
+
+	It("land should be connected", func() {
+		layout := domain.BoardLayout{
+			Resources:    map[domain.Resource]int64{domain.Ore: 1, domain.Sheep: 1},
+			NumberTokens: map[domain.NumberToken]int64{10: 1, 2: 1},
+		}
+
+		board, err := domain.ParseBoardMap("O10 ~ S2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domain.ValidateBoard(board, layout)).To(Equal(domain.BoardIsNotConnectedErr))
+
+		board, err = domain.ParseBoardMap("O10 S2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domain.ValidateBoard(board, layout)).To(Succeed())
+	})
+})
+
+var _ = Describe("Board balance", func() {
+	It("rule example board balance", func() {
+		balance := domain.NewBoardBalance(ruleExampleBoard())
+
+		Expect(balance.ResourcePips).To(Equal(map[domain.Resource]int64{
+			domain.Ore:   10,
+			domain.Wheat: 13,
+			domain.Sheep: 10,
+			domain.Brick: 12,
+			domain.Wood:  13,
+		}))
+		Expect(balance.ResourcePipsSpread()).To(Equal(int64(3)))
+
+		// wood 3, ore 8 and sheep 5
+		Expect(balance.IntersectionPips[grid.IntersectionCoord{R: 2, C: 3, D: grid.R}]).To(Equal(int64(11)))
+		Expect(balance.MaxIntersectionPips()).To(Equal(int64(11)))
+
+		Expect(balance.AdjacentRedNumbers).To(Equal(int64(0)))
+		Expect(balance.ResourceClustering).To(Equal(int64(5)))
+	})
+
+	It("generator should reject unbalanced boards", func() {
+		isBalanced := func(balance domain.BoardBalance) bool {
+			return balance.ResourcePipsSpread() <= 4 && balance.ResourceClustering <= 2
+		}
+
+		for seed := int64(0); seed < 5; seed++ {
+			board := domain.NewRandomBoardGenerator(seed).WithBalanceCheck(isBalanced).GenerateBoard()
+
+			Expect(isBalanced(domain.NewBoardBalance(board))).To(BeTrue())
+		}
+	})
+})
+
+var _ = Describe("Board", func() {
+	It("should not change the hexes it is created from", func() {
+		hexes := map[grid.HexCoord]domain.Hex{
+			{R: 0, C: 0}: {NumberToken: 10, Type: domain.HexTypeResource, Resource: domain.Ore},
+			{R: 0, C: 1}: {Type: domain.HexTypeDesert, Resource: domain.EmptyResource},
+		}
+
+		board := domain.NewBoardWithOffsetCoord(hexes)
+		otherBoard := domain.NewBoardWithOffsetCoord(hexes)
+
+		Expect(hexes[grid.HexCoord{R: 0, C: 1}].Coord).To(Equal(grid.HexCoord{}))
+
+		Expect(board.UpdateHex(grid.HexCoord{R: 0, C: 0}, domain.Hex{Type: domain.HexTypeResource, Resource: domain.Wood, NumberToken: 3})).To(Succeed())
+		Expect(hexes[grid.HexCoord{R: 0, C: 0}].Resource).To(Equal(domain.Ore))
+
+		hex, _ := otherBoard.Hex(grid.HexCoord{R: 0, C: 0})
+		Expect(hex.Resource).To(Equal(domain.Ore))
+	})
+})
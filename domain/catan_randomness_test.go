@@ -0,0 +1,222 @@
+package domain_test
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Seeded randomness", func() {
+	colors := []domain.Color{domain.Blue, domain.White, domain.Red, domain.Yellow}
+
+	rolls := func(diceRoller domain.DiceRoller) []int64 {
+		var values []int64
+		for i := 0; i < 20; i++ {
+			values = append(values, diceRoller.Roll().Value())
+		}
+
+		return values
+	}
+
+	It("players should be shuffled the same way with the same seed", func() {
+		shuffled := domain.NewRandomPlayersShuffler(7).Shuffle(colors)
+
+		Expect(shuffled).To(ConsistOf(colors))
+		Expect(domain.NewRandomPlayersShuffler(7).Shuffle(colors)).To(Equal(shuffled))
+		Expect(colors).To(Equal([]domain.Color{domain.Blue, domain.White, domain.Red, domain.Yellow}))
+	})
+
+	It("dice should roll the same way with the same seed", func() {
+		values := rolls(domain.NewRandomDiceRoller(7))
+
+		Expect(rolls(domain.NewRandomDiceRoller(7))).To(Equal(values))
+		Expect(rolls(domain.NewRandomDiceRoller(8))).NotTo(Equal(values))
+
+		for _, value := range values {
+			Expect(value).To(BeNumerically(">=", 2))
+			Expect(value).To(BeNumerically("<=", 12))
+		}
+	})
+
+	It("crypto random dice should roll valid values", func() {
+		for _, value := range rolls(domain.NewCryptoRandomDiceRoller()) {
+			Expect(value).To(BeNumerically(">=", 2))
+			Expect(value).To(BeNumerically("<=", 12))
+		}
+
+		Expect(domain.NewCryptoRandomPlayersShuffler().Shuffle(colors)).To(ConsistOf(colors))
+	})
+
+	It("seed should be recorded in the selection events", func() {
+		game := domain.NewGame("test_id", time.Now())
+
+		Expect(game.SetPlayersShuffler(domain.NewRandomPlayersShuffler(7), time.Now())).To(Succeed())
+		Expect(game.SetDiceRoller(domain.NewRandomDiceRoller(8), time.Now())).To(Succeed())
+		Expect(game.SetBoardGenerator(domain.NewRandomBoardGenerator(9), time.Now())).To(Succeed())
+		Expect(game.SetDevelopmentCardsShuffler(domain.NewRandomDevelopmentCardsShuffler(10), time.Now())).To(Succeed())
+		Expect(game.SetResourcePicker(domain.NewRandomResourcePicker(11), time.Now())).To(Succeed())
+
+		events := changedEvents(game)
+		events = events[len(events)-5:]
+
+		Expect(*events[0].(domain.PlayersShufflerSelectedEvent).Seed).To(Equal(int64(7)))
+		Expect(*events[1].(domain.DiceRollerSelected).Seed).To(Equal(int64(8)))
+		Expect(*events[2].(domain.BoardGeneratorSelectedEvent).Seed).To(Equal(int64(9)))
+		Expect(*events[3].(domain.DevelopmentCardsShufflerSelectedEvent).Seed).To(Equal(int64(10)))
+		Expect(*events[4].(domain.ResourcePickerSelectedEvent).Seed).To(Equal(int64(11)))
+	})
+
+	It("seed should not be recorded in the crypto random mode", func() {
+		game := domain.NewGame("test_id", time.Now())
+
+		Expect(game.SetPlayersShuffler(domain.NewCryptoRandomPlayersShuffler(), time.Now())).To(Succeed())
+		Expect(game.SetDiceRoller(domain.NewCryptoRandomDiceRoller(), time.Now())).To(Succeed())
+		Expect(game.SetDevelopmentCardsShuffler(domain.NewCryptoRandomDevelopmentCardsShuffler(), time.Now())).To(Succeed())
+		Expect(game.SetResourcePicker(domain.NewCryptoRandomResourcePicker(), time.Now())).To(Succeed())
+
+		events := changedEvents(game)
+		events = events[len(events)-4:]
+
+		Expect(events[0].(domain.PlayersShufflerSelectedEvent).Seed).To(BeNil())
+		Expect(events[1].(domain.DiceRollerSelected).Seed).To(BeNil())
+		Expect(events[2].(domain.DevelopmentCardsShufflerSelectedEvent).Seed).To(BeNil())
+		Expect(events[3].(domain.ResourcePickerSelectedEvent).Seed).To(BeNil())
+	})
+})
+
+var _ = Describe("Seeded game replay", func() {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	It("game should be replayed from the seeds and the commands", func() {
+		game := playSeededGame(created, 11)
+		replayed := playSeededGame(created, 11)
+
+		events := changedEvents(game)
+		Expect(events).To(ContainElement(BeAssignableToTypeOf(domain.PlayerBoughtDevelopmentCardEvent{})))
+		Expect(events).To(ContainElement(BeAssignableToTypeOf(domain.PlayerWasRobbedByPlayerEvent{})))
+
+		Expect(changedEvents(replayed)).To(Equal(events))
+		Expect(replayed.Board()).To(Equal(game.Board()))
+		Expect(replayed.TurnOrder()).To(Equal(game.TurnOrder()))
+		Expect(replayed.CurrentTurn()).To(Equal(game.CurrentTurn()))
+		Expect(replayed.RollHistory()).To(Equal(game.RollHistory()))
+		Expect(replayed.BankResources()).To(Equal(game.BankResources()))
+
+		for _, player := range game.Players() {
+			replayedPlayer, err := replayed.Player(player.Color())
+			Expect(err).To(BeNil())
+			Expect(replayedPlayer).To(Equal(player))
+		}
+	})
+
+	It("game should be played another way with another seed", func() {
+		Expect(changedEvents(playSeededGame(created, 12))).NotTo(Equal(changedEvents(playSeededGame(created, 11))))
+	})
+})
+
+// playSeededGame plays the game where every randomizer is seeded,
+// players place the first possible buildings, discard the first cards, rob the first possible opponent and buy development cards
+func playSeededGame(created time.Time, seed int64) *domain.Game {
+	const turns = 100
+
+	calculator := grid.HexagonGridWithOffsetCoordsCalculator{}
+	game := domain.NewGame("replay", created)
+
+	for _, player := range []domain.Player{
+		domain.NewPlayer(domain.Blue, "baska"),
+		domain.NewPlayer(domain.White, "bot"),
+		domain.NewPlayer(domain.Red, "masha"),
+		domain.NewPlayer(domain.Yellow, "vasya"),
+	} {
+		Expect(game.AddPlayer(player, created)).To(Succeed())
+	}
+
+	Expect(game.SetBoardGenerator(domain.NewRandomBoardGenerator(seed), created)).To(Succeed())
+	Expect(game.SetPlayersShuffler(domain.NewRandomPlayersShuffler(seed), created)).To(Succeed())
+	Expect(game.SetDiceRoller(domain.NewRandomDiceRoller(seed), created)).To(Succeed())
+	Expect(game.SetDevelopmentCardsShuffler(domain.NewRandomDevelopmentCardsShuffler(seed), created)).To(Succeed())
+	Expect(game.SetResourcePicker(domain.NewRandomResourcePicker(seed), created)).To(Succeed())
+	Expect(game.StartGame(created)).To(Succeed())
+
+	intersectionCoords := func() []grid.IntersectionCoord {
+		var coords []grid.IntersectionCoord
+		for _, intersection := range game.Board().Intersections() {
+			coords = append(coords, intersection.Coord())
+		}
+
+		sort.Slice(coords, func(i, j int) bool {
+			return fmt.Sprint(coords[i]) < fmt.Sprint(coords[j])
+		})
+
+		return coords
+	}
+
+	for game.InState(&domain.GameStateInitialSetup{}) {
+		playerColor := game.CurrentTurn()
+
+		var settlementCoord grid.IntersectionCoord
+		for _, coord := range intersectionCoords() {
+			if game.PlaceSettlement(playerColor, domain.NewSettlement(playerColor, coord), created) == nil {
+				settlementCoord = coord
+				break
+			}
+		}
+
+		placed := false
+		for _, pathCoord := range calculator.IntersectionAdjacentPaths(settlementCoord) {
+			if game.PlaceRoad(playerColor, domain.NewRoad(pathCoord, playerColor), created) == nil {
+				placed = true
+				break
+			}
+		}
+		Expect(placed).To(BeTrue())
+	}
+
+	Expect(game.InState(&domain.GameStatePlay{})).To(BeTrue())
+
+	for turn := 0; turn < turns && game.InState(&domain.GameStatePlay{}); turn++ {
+		playerColor := game.CurrentTurn()
+
+		Expect(game.RollDice(playerColor, created)).To(Succeed())
+
+		for _, color := range game.TurnOrder() {
+			player, err := game.Player(color)
+			Expect(err).To(BeNil())
+
+			resources := player.Resources()
+			_ = game.DiscardResources(color, resources[:len(resources)/2], created)
+		}
+
+		var hexCoords []grid.HexCoord
+		for _, hex := range game.Board().Hexes() {
+			hexCoords = append(hexCoords, hex.Coord)
+		}
+
+		sort.Slice(hexCoords, func(i, j int) bool {
+			return fmt.Sprint(hexCoords[i]) < fmt.Sprint(hexCoords[j])
+		})
+
+		for _, hexCoord := range hexCoords {
+			if game.PlaceRobber(playerColor, hexCoord, created) == nil {
+				break
+			}
+		}
+
+		for _, targetColor := range game.TurnOrder() {
+			if game.RobPlayer(playerColor, targetColor, created) == nil {
+				break
+			}
+		}
+
+		_ = game.BuyDevelopmentCard(playerColor, created)
+
+		Expect(game.EndTurn(playerColor, created)).To(Succeed())
+	}
+
+	return game
+}
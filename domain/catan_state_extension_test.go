@@ -0,0 +1,192 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan 5-6 player extension", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		game = domain.NewGame("test_id", time.Now())
+
+		Expect(game.SetGameRules(domain.ExtensionGameRules(), time.Now())).To(Succeed())
+	})
+
+	It("bank should have more resources", func() {
+		Expect(game.BankResources()[domain.ResourceCardOre]).To(Equal(int64(domain.ExtensionBankResourcesPerType)))
+	})
+
+	When("four players joined", func() {
+		BeforeEach(func() {
+			for _, color := range []domain.Color{domain.Blue, domain.White, domain.Red, domain.Yellow} {
+				Expect(game.AddPlayer(domain.NewPlayer(color, ""), time.Now())).To(Succeed())
+			}
+
+			Expect(game.SetBoardGenerator(testBoardGenerator{}, time.Now())).To(Succeed())
+			Expect(game.SetPlayersShuffler(simplePlayersShuffler{}, time.Now())).To(Succeed())
+			Expect(game.SetDiceRoller(domain.NewRandomDiceRoller(1), time.Now())).To(Succeed())
+		})
+
+		It("game cannot be started", func() {
+			Expect(game.StartGame(time.Now())).To(Equal(domain.NotEnoughPlayersErr))
+		})
+
+		When("two more players joined", func() {
+			BeforeEach(func() {
+				Expect(game.AddPlayer(domain.NewPlayer(domain.Green, ""), time.Now())).To(Succeed())
+				Expect(game.AddPlayer(domain.NewPlayer(domain.Orange, ""), time.Now())).To(Succeed())
+			})
+
+			It("the seventh player cannot join", func() {
+				Expect(game.AddPlayer(domain.NewPlayer(domain.Black, ""), time.Now())).To(Equal(domain.GameIsFullErr))
+			})
+
+			It("game should be started", func() {
+				Expect(game.StartGame(time.Now())).To(Succeed())
+			})
+		})
+	})
+
+	It("extension layout should have 30 land hexes", func() {
+		layout := domain.ExtensionBoardLayout()
+
+		var resources, numberTokens int64
+		for _, count := range layout.Resources {
+			resources += count
+		}
+
+		for _, count := range layout.NumberTokens {
+			numberTokens += count
+		}
+
+		Expect(layout.HexCoords()).To(HaveLen(30))
+		Expect(resources).To(Equal(int64(30)))
+		Expect(numberTokens).To(Equal(int64(28)))
+	})
+
+	It("extension board should be generated", func() {
+		board := domain.NewRandomBoardGeneratorWithLayout(domain.ExtensionBoardLayout(), 1).GenerateBoard()
+
+		deserts, landHexes := 0, 0
+		for _, hex := range board.Hexes() {
+			if !hex.IsLand() {
+				continue
+			}
+
+			landHexes++
+
+			if hex.Type == domain.HexTypeDesert {
+				deserts++
+				Expect(hex.NumberToken).To(Equal(domain.NumberTokenEmpty))
+				continue
+			}
+
+			Expect(hex.NumberToken).NotTo(Equal(domain.NumberTokenEmpty))
+		}
+
+		Expect(landHexes).To(Equal(30))
+		Expect(deserts).To(Equal(2))
+	})
+})
+
+var _ = Describe("Catan state play special building phase", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		rules := domain.DefaultGameRules()
+		rules.SpecialBuildingPhase = true
+
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.GameRulesSelectedEvent{GameRules: rules})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	It("white should build first", func() {
+		events := changedEvents(game)
+		Expect(events[len(events)-3:]).To(Equal([]interface{}{
+			domain.PlayerFinishedHisTurnEvent{PlayerColor: domain.Blue},
+			domain.SpecialBuildingPhaseStartedEvent{PlayerColors: []domain.Color{domain.White, domain.Red, domain.Yellow}},
+			domain.PlayerStartedSpecialBuildingEvent{PlayerColor: domain.White},
+		}))
+		Expect(game.CurrentTurn()).To(Equal(domain.White))
+	})
+
+	It("white can build a road", func() {
+		road := domain.NewRoad(grid.PathCoord{R: 3, C: 4, D: grid.N}, domain.White)
+		pickResources(game, domain.White, road.Cost()...)
+
+		Expect(game.PlaceRoad(domain.White, road, time.Now())).To(Succeed())
+	})
+
+	It("white cannot trade", func() {
+		Expect(game.ProposeTrade(domain.White, []domain.ResourceCard{domain.ResourceCardWood}, []domain.ResourceCard{domain.ResourceCardOre}, nil, time.Now())).To(Equal(domain.TradeIsNotAllowedErr))
+
+		pickResources(game, domain.White, domain.Wood.GetResourceCard(4)...)
+		Expect(game.MaritimeTrade(domain.White, domain.ResourceCardWood, domain.ResourceCardSheep, time.Now())).To(Equal(domain.TradeIsNotAllowedErr))
+	})
+
+	It("white cannot roll dice", func() {
+		Expect(game.RollDice(domain.White, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+	})
+
+	It("blue cannot build anymore", func() {
+		road := domain.NewRoad(grid.PathCoord{R: 3, C: 3, D: grid.N}, domain.Blue)
+		pickResources(game, domain.Blue, road.Cost()...)
+
+		Expect(game.PlaceRoad(domain.Blue, road, time.Now())).To(Equal(domain.WrongTurnErr))
+	})
+
+	When("everybody has built", func() {
+		BeforeEach(func() {
+			for _, color := range []domain.Color{domain.White, domain.Red, domain.Yellow} {
+				Expect(game.EndTurn(color, time.Now())).To(Succeed())
+			}
+		})
+
+		It("white should start his turn", func() {
+			events := changedEvents(game)
+			Expect(events[len(events)-3:]).To(Equal([]interface{}{
+				domain.PlayerFinishedSpecialBuildingEvent{PlayerColor: domain.Yellow},
+				domain.SpecialBuildingPhaseFinishedEvent{},
+				domain.PlayerStartedHisTurnEvent{PlayerColor: domain.White},
+			}))
+
+			Expect(game.RollDice(domain.White, time.Now())).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Catan state play special building phase of one player", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		rules := domain.DefaultGameRules()
+		rules.MinPlayers = 1
+		rules.SpecialBuildingPhase = true
+		Expect(rules.Validate()).To(Succeed())
+
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayIsland(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.GameRulesSelectedEvent{GameRules: rules})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	It("phase should be skipped", func() {
+		events := changedEvents(game)
+		Expect(events[len(events)-2:]).To(Equal([]interface{}{
+			domain.PlayerFinishedHisTurnEvent{PlayerColor: domain.Blue},
+			domain.PlayerStartedHisTurnEvent{PlayerColor: domain.Blue},
+		}))
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+})
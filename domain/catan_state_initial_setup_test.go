@@ -92,6 +92,8 @@ var _ = Describe("Catan state initial setup", func() {
 		Expect(game.SetBoardGenerator(testBoardGenerator{}, time.Now())).To(BeNil())
 		// set players shuffler
 		Expect(game.SetPlayersShuffler(simplePlayersShuffler{}, time.Now())).To(BeNil())
+		// set dice roller
+		Expect(game.SetDiceRoller(domain.NewRandomDiceRoller(1), time.Now())).To(BeNil())
 
 		Expect(game.StartGame(time.Now())).To(BeNil())
 	})
@@ -120,7 +122,7 @@ var _ = Describe("Catan state initial setup", func() {
 	})
 
 	It("should have correct version", func() {
-		Expect(game.Version()).To(Equal(int64(12)))
+		Expect(game.Version()).To(Equal(int64(14)))
 	})
 
 	Specify("no error", func() {
@@ -179,6 +181,12 @@ var _ = Describe("Catan state initial setup", func() {
 			})
 		})
 
+		When("first player tries to build a road of another color", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceRoad(game.CurrentTurn(), domain.NewRoad(grid.PathCoord{R: 3, C: 1, D: grid.E}, game.NextTurnColor()), time.Now())).To(Equal(domain.WrongPieceColorErr))
+			})
+		})
+
 		When("first player tries to build an illegal road", func() {
 			It("should receive an error", func() {
 				Expect(game.PlaceRoad(game.CurrentTurn(), domain.NewRoad(grid.PathCoord{R: 1, C: 2, D: grid.E}, game.CurrentTurn()), time.Now())).To(Equal(domain.CommandIsForbiddenErr))
@@ -219,7 +227,7 @@ var _ = Describe("Catan state initial setup", func() {
 
 	When("current player tries to buy a development card", func() {
 		It("should receive an error", func() {
-			Expect(game.BuyDevelopmentCard(game.CurrentTurn())).To(Equal(domain.CommandIsForbiddenErr))
+			Expect(game.BuyDevelopmentCard(game.CurrentTurn(), time.Now())).To(Equal(domain.CommandIsForbiddenErr))
 		})
 	})
 
@@ -247,6 +255,13 @@ var _ = Describe("Catan state initial setup", func() {
 		})
 	})
 
+	When("first player tries to build a settlement of another color", func() {
+		It("should receive an error", func() {
+			err := game.PlaceSettlement(game.CurrentTurn(), domain.NewSettlement(game.NextTurnColor(), grid.IntersectionCoord{R: 3, C: 3, D: grid.R}), startGameCommandOccurred)
+			Expect(err).To(Equal(domain.WrongPieceColorErr))
+		})
+	})
+
 	When("not first player tries to build settlement", func() {
 		It("should receive an error", func() {
 			err := game.PlaceSettlement(game.NextTurnColor(), domain.NewSettlement(game.NextTurnColor(), grid.IntersectionCoord{R: 3, C: 3, D: grid.R}), startGameCommandOccurred)
@@ -539,5 +554,34 @@ var _ = Describe("Catan state initial setup", func() {
 				Expect(player.Resources()).To(Equal(expectedResources))
 			}
 		})
+
+		It("starting resources should be received for the second settlements only", func() {
+			var startingResourcesEvents []interface{}
+
+			for _, event := range changedEvents(game) {
+				if _, ok := event.(domain.PlayerReceivedStartingResourcesEvent); ok {
+					startingResourcesEvents = append(startingResourcesEvents, event)
+				}
+			}
+
+			Expect(startingResourcesEvents).To(Equal([]interface{}{
+				domain.PlayerReceivedStartingResourcesEvent{
+					PlayerColor: domain.Yellow,
+					Resources:   []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardWheat, domain.ResourceCardWheat},
+				},
+				domain.PlayerReceivedStartingResourcesEvent{
+					PlayerColor: domain.Red,
+					Resources:   []domain.ResourceCard{domain.ResourceCardWheat, domain.ResourceCardWood, domain.ResourceCardWood},
+				},
+				domain.PlayerReceivedStartingResourcesEvent{
+					PlayerColor: domain.White,
+					Resources:   []domain.ResourceCard{domain.ResourceCardWheat, domain.ResourceCardBrick, domain.ResourceCardWood},
+				},
+				domain.PlayerReceivedStartingResourcesEvent{
+					PlayerColor: domain.Blue,
+					Resources:   []domain.ResourceCard{domain.ResourceCardWood, domain.ResourceCardOre, domain.ResourceCardBrick},
+				},
+			}))
+		})
 	})
 })
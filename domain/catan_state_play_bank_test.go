@@ -0,0 +1,103 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play bank", func() {
+	var (
+		game       *domain.Game
+		diceRoller *fixedDiceRoller
+	)
+
+	BeforeEach(func() {
+		diceRoller = &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+	})
+
+	It("starting resources should be taken from the bank", func() {
+		Expect(game.BankResources()).To(Equal(map[domain.ResourceCard]int64{
+			domain.ResourceCardOre:   17,
+			domain.ResourceCardWheat: 15,
+			domain.ResourceCardSheep: 19,
+			domain.ResourceCardBrick: 17,
+			domain.ResourceCardWood:  15,
+		}))
+	})
+
+	When("blue rolled dice and built a road", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+			Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 3, C: 3, D: grid.N}, domain.Blue), time.Now())).To(Succeed())
+		})
+
+		It("produced resources should be taken from the bank and paid ones returned", func() {
+			bankResources := game.BankResources()
+			Expect(bankResources[domain.ResourceCardBrick]).To(Equal(int64(16)))
+			Expect(bankResources[domain.ResourceCardWheat]).To(Equal(int64(14)))
+			Expect(bankResources[domain.ResourceCardWood]).To(Equal(int64(16)))
+		})
+	})
+
+	When("the bank can't give brick to white and red", func() {
+		BeforeEach(func() {
+			pickResources(game, domain.Yellow, domain.Brick.GetResourceCard(16)...)
+
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("nobody should get brick", func() {
+			white, err := game.Player(domain.White)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(white.Resources()).To(HaveLen(3))
+
+			red, err := game.Player(domain.Red)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(red.Resources()).To(HaveLen(3))
+
+			Expect(game.BankResources()[domain.ResourceCardBrick]).To(Equal(int64(1)))
+		})
+
+		It("other resources should be given", func() {
+			Expect(game.LastEvent()).To(Equal(domain.PlayerPickedResourcesEvent{
+				PlayerColor:     domain.Yellow,
+				PickedResources: []domain.ResourceCard{domain.ResourceCardWheat},
+			}))
+		})
+	})
+
+	When("the bank can't give sheep to the only player", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+
+			city := domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 3, C: 3, D: grid.R})
+			pickResources(game, domain.Blue, city.Cost()...)
+			Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Succeed())
+
+			pickResources(game, domain.Yellow, domain.Sheep.GetResourceCard(18)...)
+
+			diceRoller.roll = domain.NewRoll(domain.D6Roll5, domain.D6Roll6)
+			startTurn(game, domain.Blue)
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("he should get what is left", func() {
+			Expect(changedEvents(game)).To(ContainElement(domain.PlayerPickedResourcesEvent{
+				PlayerColor:     domain.Blue,
+				PickedResources: []domain.ResourceCard{domain.ResourceCardSheep},
+			}))
+			Expect(game.BankResources()[domain.ResourceCardSheep]).To(Equal(int64(0)))
+		})
+
+		It("he cannot take sheep by maritime trade", func() {
+			pickResources(game, domain.Blue, domain.Wood.GetResourceCard(4)...)
+
+			Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardSheep, time.Now())).To(Equal(domain.BankIsOutOfResourcesErr))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play cities", func() {
+	var (
+		game       *domain.Game
+		diceRoller *fixedDiceRoller
+	)
+
+	city := domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 3, C: 3, D: grid.R})
+
+	BeforeEach(func() {
+		diceRoller = &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.ResourcePickerSelectedEvent{ResourcePicker: firstResourcePicker{}})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("blue places a city without resources", func() {
+		It("should receive an error", func() {
+			Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Equal(domain.NotEnoughResourcesErr))
+		})
+	})
+
+	When("blue has resources for a city", func() {
+		BeforeEach(func() {
+			pickResources(game, domain.Blue, city.Cost()...)
+		})
+
+		When("blue places a city on the empty intersection", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceCity(domain.Blue, domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 2, C: 2, D: grid.R}), time.Now())).To(Equal(domain.NoSettlementToUpgradeErr))
+			})
+		})
+
+		When("blue places a city on the white settlement", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceCity(domain.Blue, domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 2, C: 3, D: grid.R}), time.Now())).To(Equal(domain.NoSettlementToUpgradeErr))
+			})
+		})
+
+		When("blue upgrades the red settlement to a red city", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceCity(domain.Blue, domain.NewCity(domain.Red, grid.IntersectionCoord{R: 2, C: 0, D: grid.R}), time.Now())).To(Equal(domain.WrongPieceColorErr))
+
+				intersection, exists := game.Board().Intersection(grid.IntersectionCoord{R: 2, C: 0, D: grid.R})
+				Expect(exists).To(BeTrue())
+				Expect(intersection.Building()).To(Equal(domain.NewSettlement(domain.Red, grid.IntersectionCoord{R: 2, C: 0, D: grid.R})))
+			})
+		})
+
+		When("blue upgrades his settlement", func() {
+			BeforeEach(func() {
+				Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Succeed())
+			})
+
+			It("blue should pay for the city and get the settlement back", func() {
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardWood, domain.ResourceCardOre, domain.ResourceCardBrick))
+				Expect(blue.AvailableCities()).To(Equal(int64(3)))
+				Expect(blue.AvailableSettlements()).To(Equal(int64(4)))
+				Expect(blue.VictoryPoints()).To(Equal(int64(3)))
+			})
+
+			It("city should be on the board", func() {
+				intersection, exists := game.Board().Intersection(city.IntersectionCoord())
+				Expect(exists).To(BeTrue())
+				Expect(intersection.Building()).To(Equal(city))
+			})
+
+			It("city cannot be upgraded again", func() {
+				pickResources(game, domain.Blue, city.Cost()...)
+
+				Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Equal(domain.NoSettlementToUpgradeErr))
+			})
+
+			It("city should produce two resources", func() {
+				diceRoller.roll = domain.NewRoll(domain.D6Roll5, domain.D6Roll6)
+				startTurn(game, domain.Blue)
+
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+				Expect(changedEvents(game)).To(ContainElement(domain.PlayerPickedResourcesEvent{
+					PlayerColor:     domain.Blue,
+					PickedResources: []domain.ResourceCard{domain.ResourceCardSheep, domain.ResourceCardSheep},
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,471 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play development cards", func() {
+	var (
+		game       *domain.Game
+		diceRoller *fixedDiceRoller
+	)
+
+	BeforeEach(func() {
+		diceRoller = &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.ResourcePickerSelectedEvent{ResourcePicker: firstResourcePicker{}})
+
+		pickResources(game, domain.Blue, domain.ResourceCardWheat, domain.ResourceCardSheep)
+	})
+
+	It("deck should have 25 cards", func() {
+		Expect(game.AvailableDevelopmentCards()).To(Equal(int64(25)))
+
+		cardsCount := make(map[domain.DevelopmentCardType]int)
+		for _, card := range domain.NewDevelopmentCardsDeck() {
+			cardsCount[card.Type()]++
+		}
+
+		Expect(cardsCount).To(Equal(map[domain.DevelopmentCardType]int{
+			domain.Knight:       14,
+			domain.VictoryPoint: 5,
+			domain.RoadBuilding: 2,
+			domain.YearOfPlenty: 2,
+			domain.Monopoly:     2,
+		}))
+	})
+
+	When("blue buys a card before rolling dice", func() {
+		It("should receive an error", func() {
+			Expect(game.BuyDevelopmentCard(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+		})
+	})
+
+	When("blue rolled dice", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		When("not current player buys a card", func() {
+			It("should receive an error", func() {
+				Expect(game.BuyDevelopmentCard(domain.Red, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("blue buys a card", func() {
+			BeforeEach(func() {
+				Expect(game.BuyDevelopmentCard(domain.Blue, time.Now())).To(Succeed())
+			})
+
+			It("blue should pay for the card", func() {
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.Resources()).To(Equal([]domain.ResourceCard{domain.ResourceCardWood, domain.ResourceCardBrick}))
+			})
+
+			It("blue should get the top card of the deck", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerBoughtDevelopmentCardEvent{PlayerColor: domain.Blue, DevelopmentCard: domain.DevelopmentCardKnight}))
+				Expect(game.AvailableDevelopmentCards()).To(Equal(int64(24)))
+
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.DevelopmentCards()).To(Equal([]domain.DevelopmentCard{domain.DevelopmentCardKnight}))
+			})
+
+			It("the card cannot be played on the same turn", func() {
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.CanPlayDevelopmentCard(domain.DevelopmentCardKnight)).To(Equal(domain.DevelopmentCardIsNewErr))
+			})
+
+			When("blue buys another card without resources", func() {
+				It("should receive an error", func() {
+					Expect(game.BuyDevelopmentCard(domain.Blue, time.Now())).To(Equal(domain.NotEnoughResourcesErr))
+				})
+			})
+
+			When("blue plays the card on the same turn", func() {
+				It("should receive an error", func() {
+					Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardKnight, time.Now())).To(Equal(domain.DevelopmentCardIsNewErr))
+				})
+			})
+		})
+	})
+
+	When("blue has knights from the previous turn", func() {
+		BeforeEach(func() {
+			giveDevelopmentCards(game, domain.Blue, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight)
+		})
+
+		When("not current player plays a knight", func() {
+			It("should receive an error", func() {
+				Expect(game.PlayDevelopmentCard(domain.White, domain.DevelopmentCardKnight, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("blue plays a card he doesn't have", func() {
+			It("should receive an error", func() {
+				Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardMonopoly, time.Now())).To(Equal(domain.DevelopmentCardIsNotOwnedErr))
+			})
+		})
+
+		When("blue plays a knight before rolling dice", func() {
+			BeforeEach(func() {
+				Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardKnight, time.Now())).To(Succeed())
+			})
+
+			It("knight should be counted", func() {
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.PlayedKnights()).To(Equal(int64(1)))
+				Expect(blue.DevelopmentCards()).To(HaveLen(3))
+				Expect(blue.LargestArmyOwner()).To(BeFalse())
+			})
+
+			It("dice cannot be rolled until the robber is moved", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			When("blue moves the robber and robs yellow", func() {
+				BeforeEach(func() {
+					Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 4, C: 3}, time.Now())).To(Succeed())
+				})
+
+				It("yellow should be robbed", func() {
+					Expect(game.LastEvent()).To(Equal(domain.PlayerWasRobbedByPlayerEvent{
+						RobbingPlayerColor: domain.Blue,
+						RobbedPlayerColor:  domain.Yellow,
+						DumpedResources:    []domain.ResourceCard{domain.ResourceCardOre},
+					}))
+				})
+
+				It("blue should roll dice", func() {
+					Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+				})
+			})
+		})
+
+		When("blue plays a knight after rolling dice", func() {
+			BeforeEach(func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+				Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardKnight, time.Now())).To(Succeed())
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 4, C: 4}, time.Now())).To(Succeed())
+			})
+
+			It("blue should go on with his turn", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				Expect(game.BuyDevelopmentCard(domain.Blue, time.Now())).To(Succeed())
+			})
+		})
+
+		When("blue plays three knights", func() {
+			BeforeEach(func() {
+				playKnight(game, domain.Blue, grid.HexCoord{R: 4, C: 4})
+				startTurn(game, domain.Blue)
+				playKnight(game, domain.Blue, grid.HexCoord{R: 2, C: 2})
+				startTurn(game, domain.Blue)
+				playKnight(game, domain.Blue, grid.HexCoord{R: 4, C: 4})
+			})
+
+			It("blue should get largest army", func() {
+				Expect(changedEvents(game)).To(ContainElement(domain.LargestArmyOwnerChangedEvent{PlayerColor: domain.Blue, PreviousOwnerColor: domain.None}))
+
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.LargestArmyOwner()).To(BeTrue())
+				Expect(blue.VictoryPoints()).To(Equal(int64(4)))
+			})
+
+			When("white plays three knights too", func() {
+				BeforeEach(func() {
+					giveDevelopmentCards(game, domain.White, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight, domain.DevelopmentCardKnight)
+
+					playKnight(game, domain.White, grid.HexCoord{R: 2, C: 2})
+					startTurn(game, domain.White)
+					playKnight(game, domain.White, grid.HexCoord{R: 4, C: 4})
+					startTurn(game, domain.White)
+					playKnight(game, domain.White, grid.HexCoord{R: 2, C: 2})
+				})
+
+				It("blue should keep largest army", func() {
+					Expect(changedEvents(game)).NotTo(ContainElement(domain.LargestArmyOwnerChangedEvent{PlayerColor: domain.White, PreviousOwnerColor: domain.Blue}))
+
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.LargestArmyOwner()).To(BeTrue())
+
+					white, err := game.Player(domain.White)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(white.LargestArmyOwner()).To(BeFalse())
+				})
+
+				When("white plays the fourth knight", func() {
+					BeforeEach(func() {
+						startTurn(game, domain.White)
+						playKnight(game, domain.White, grid.HexCoord{R: 4, C: 4})
+					})
+
+					It("largest army should pass to white", func() {
+						Expect(changedEvents(game)).To(ContainElement(domain.LargestArmyOwnerChangedEvent{PlayerColor: domain.White, PreviousOwnerColor: domain.Blue}))
+
+						blue, err := game.Player(domain.Blue)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(blue.LargestArmyOwner()).To(BeFalse())
+						Expect(blue.VictoryPoints()).To(Equal(int64(2)))
+
+						white, err := game.Player(domain.White)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(white.LargestArmyOwner()).To(BeTrue())
+						Expect(white.VictoryPoints()).To(Equal(int64(4)))
+					})
+				})
+			})
+		})
+	})
+
+	When("blue has progress cards from the previous turn", func() {
+		BeforeEach(func() {
+			giveDevelopmentCards(game, domain.Blue, domain.DevelopmentCardKnight, domain.DevelopmentCardRoadBuilding, domain.DevelopmentCardYearOfPlenty, domain.DevelopmentCardMonopoly)
+		})
+
+		When("blue plays a victory point card", func() {
+			It("should receive an error", func() {
+				giveDevelopmentCards(game, domain.Blue, domain.DevelopmentCardVictoryPoint)
+
+				Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardVictoryPoint, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+
+		When("blue plays road building", func() {
+			BeforeEach(func() {
+				Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardRoadBuilding, time.Now())).To(Succeed())
+			})
+
+			It("dice cannot be rolled until roads are placed", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			It("another card cannot be played on the same turn", func() {
+				Expect(game.PlayMonopoly(domain.Blue, domain.Wheat, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			When("blue places a road on the occupied path", func() {
+				It("should receive an error", func() {
+					Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 3, C: 3, D: grid.E}, domain.Blue), time.Now())).To(Equal(domain.BadPathCoordErr))
+				})
+			})
+
+			When("blue places two free roads", func() {
+				BeforeEach(func() {
+					Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 3, C: 4, D: grid.W}, domain.Blue), time.Now())).To(Succeed())
+					Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 3, C: 3, D: grid.N}, domain.Blue), time.Now())).To(Succeed())
+				})
+
+				It("roads should be free", func() {
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.AvailableRoads()).To(Equal(int64(11)))
+					Expect(blue.Resources()).To(HaveLen(5))
+
+					path, exists := game.Board().Path(grid.PathCoord{R: 3, C: 3, D: grid.N})
+					Expect(exists).To(BeTrue())
+					Expect(path.IsEmpty()).To(BeFalse())
+				})
+
+				It("blue should roll dice", func() {
+					Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+				})
+			})
+		})
+
+		When("blue plays year of plenty", func() {
+			When("blue takes not two resources", func() {
+				It("should receive an error", func() {
+					Expect(game.PlayYearOfPlenty(domain.Blue, []domain.ResourceCard{domain.ResourceCardOre}, time.Now())).To(Equal(domain.WrongNumberOfResourcesToTakeErr))
+				})
+			})
+
+			When("blue takes two resources", func() {
+				BeforeEach(func() {
+					Expect(game.PlayYearOfPlenty(domain.Blue, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre}, time.Now())).To(Succeed())
+				})
+
+				It("blue should receive them", func() {
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.ResourceCount(domain.Ore)).To(Equal(int64(3)))
+					Expect(blue.DevelopmentCards()).To(Equal([]domain.DevelopmentCard{domain.DevelopmentCardKnight, domain.DevelopmentCardRoadBuilding, domain.DevelopmentCardMonopoly}))
+				})
+
+				It("another card cannot be played on the same turn", func() {
+					Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardKnight, time.Now())).To(Equal(domain.DevelopmentCardAlreadyPlayedErr))
+				})
+
+				It("another card can be played on the next turn", func() {
+					startTurn(game, domain.Blue)
+
+					Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardKnight, time.Now())).To(Succeed())
+				})
+			})
+		})
+
+		When("blue plays monopoly", func() {
+			BeforeEach(func() {
+				Expect(game.PlayMonopoly(domain.Blue, domain.Wheat, time.Now())).To(Succeed())
+			})
+
+			It("blue should collect wheat from all opponents", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerCollectedMonopolyResourcesEvent{
+					PlayerColor: domain.Blue,
+					Resource:    domain.Wheat,
+					CollectedResources: map[domain.Color]int64{
+						domain.White:  1,
+						domain.Red:    1,
+						domain.Yellow: 2,
+					},
+				}))
+
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.ResourceCount(domain.Wheat)).To(Equal(int64(5)))
+
+				yellow, err := game.Player(domain.Yellow)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(yellow.Resources()).To(Equal([]domain.ResourceCard{domain.ResourceCardOre}))
+			})
+		})
+	})
+})
+
+// changedEvents returns all events applied to the game
+func changedEvents(game *domain.Game) []interface{} {
+	var events []interface{}
+
+	for _, eventMessage := range game.Changes() {
+		events = append(events, eventMessage.Event())
+	}
+
+	return events
+}
+
+// startTurn finishes the current turn and starts the turn of the player
+func startTurn(game *domain.Game, playerColor domain.Color) {
+	game.Apply(domain.NewEventDescriptor(game.Id(), domain.PlayerFinishedHisTurnEvent{PlayerColor: game.CurrentTurn()}, nil, game.Version(), time.Now()), true)
+	game.Apply(domain.NewEventDescriptor(game.Id(), domain.PlayerStartedHisTurnEvent{PlayerColor: playerColor}, nil, game.Version(), time.Now()), true)
+}
+
+// giveDevelopmentCards gives development cards to the player as if he bought them on his previous turn
+func giveDevelopmentCards(game *domain.Game, playerColor domain.Color, cards ...domain.DevelopmentCard) {
+	startTurn(game, playerColor)
+
+	for _, card := range cards {
+		pickResources(game, playerColor, card.Cost()...)
+
+		game.Apply(domain.NewEventDescriptor(game.Id(), domain.PlayerBoughtDevelopmentCardEvent{
+			PlayerColor:     playerColor,
+			DevelopmentCard: card,
+		}, nil, game.Version(), time.Now()), true)
+	}
+
+	startTurn(game, playerColor)
+}
+
+// playKnight plays a knight before rolling dice and moves the robber to the hex without opponents
+func playKnight(game *domain.Game, playerColor domain.Color, hexCoord grid.HexCoord) {
+	Expect(game.PlayDevelopmentCard(playerColor, domain.DevelopmentCardKnight, time.Now())).To(Succeed())
+	Expect(game.PlaceRobber(playerColor, hexCoord, time.Now())).To(Succeed())
+}
+
+var _ = Describe("Catan state play road building on the island", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		game = replayIsland(domain.DiceRollerSelected{DiceRoller: &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}})
+
+		giveDevelopmentCards(game, domain.Blue, domain.DevelopmentCardRoadBuilding)
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("blue has roads on all paths", func() {
+		BeforeEach(func() {
+			buildRoads(game, domain.Blue,
+				grid.PathCoord{R: 0, C: 0, D: grid.E},
+				grid.PathCoord{R: 1, C: 1, D: grid.W},
+				grid.PathCoord{R: 0, C: -1, D: grid.E},
+				grid.PathCoord{R: 0, C: 0, D: grid.W},
+			)
+		})
+
+		It("road building cannot be played", func() {
+			Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardRoadBuilding, time.Now())).To(Equal(domain.NoPlaceForRoadErr))
+		})
+	})
+
+	When("blue has the place for one road only", func() {
+		BeforeEach(func() {
+			buildRoads(game, domain.Blue,
+				grid.PathCoord{R: 0, C: 0, D: grid.E},
+				grid.PathCoord{R: 1, C: 1, D: grid.W},
+				grid.PathCoord{R: 0, C: -1, D: grid.E},
+			)
+
+			Expect(game.PlayDevelopmentCard(domain.Blue, domain.DevelopmentCardRoadBuilding, time.Now())).To(Succeed())
+			Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 0, C: 0, D: grid.W}, domain.Blue), time.Now())).To(Succeed())
+		})
+
+		It("the second free road should be skipped", func() {
+			Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+		})
+	})
+})
+
+// replayIsland replays the game of blue alone on the single hex, blue settlements are on the opposite corners
+func replayIsland(selectionEvents ...interface{}) *domain.Game {
+	board, err := domain.ParseBoardMap("O10")
+	Expect(err).NotTo(HaveOccurred())
+
+	events := []interface{}{
+		domain.GameCreated{GameId: "island"},
+		domain.PlayerJoinedTheGameEvent{Player: domain.NewPlayer(domain.Blue, "baska")},
+	}
+
+	events = append(events, selectionEvents...)
+	events = append(events,
+		domain.GameStartedEvent{},
+		domain.BoardGeneratedEvent{NewBoard: board},
+		domain.PlayersShuffledEvent{PlayersInOrder: []domain.Color{domain.Blue}},
+		domain.DevelopmentCardsShuffledEvent{DevelopmentCards: domain.NewDevelopmentCardsDeck()},
+		domain.InitialSetupPhaseStartedEvent{},
+	)
+
+	for _, building := range []struct {
+		settlement grid.IntersectionCoord
+		road       grid.PathCoord
+	}{
+		{settlement: grid.IntersectionCoord{R: -1, C: -1, D: grid.R}, road: grid.PathCoord{R: 0, C: 0, D: grid.N}},
+		{settlement: grid.IntersectionCoord{R: 1, C: 1, D: grid.L}, road: grid.PathCoord{R: 1, C: 0, D: grid.N}},
+	} {
+		events = append(events,
+			domain.PlayerStartedHisTurnEvent{PlayerColor: domain.Blue},
+			domain.PlayerPlacedSettlementEvent{PlayerColor: domain.Blue, Settlement: domain.NewSettlement(domain.Blue, building.settlement)},
+			domain.PlayerPlacedRoadEvent{PlayerColor: domain.Blue, Road: domain.NewRoad(building.road, domain.Blue)},
+			domain.PlayerFinishedHisTurnEvent{PlayerColor: domain.Blue},
+		)
+	}
+
+	events = append(events,
+		domain.PlayPhaseStartedEvent{},
+		domain.PlayerStartedHisTurnEvent{PlayerColor: domain.Blue},
+	)
+
+	game := &domain.Game{}
+	for _, event := range events {
+		game.Apply(domain.NewEventDescriptor(game.Id(), event, nil, game.Version(), time.Now()), true)
+	}
+
+	return game
+}
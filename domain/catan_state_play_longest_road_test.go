@@ -0,0 +1,154 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play longest road", func() {
+	var game *domain.Game
+
+	// blue road goes on from the (3,3,E) road of the initial setup
+	blueRoads := []grid.PathCoord{
+		{R: 3, C: 3, D: grid.N},
+		{R: 2, C: 2, D: grid.E},
+		{R: 2, C: 3, D: grid.W},
+		{R: 2, C: 3, D: grid.N},
+	}
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.ResourcePickerSelectedEvent{ResourcePicker: firstResourcePicker{}})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("blue builds a white road", func() {
+		It("should receive an error", func() {
+			road := domain.NewRoad(grid.PathCoord{R: 2, C: 1, D: grid.N}, domain.White)
+			pickResources(game, domain.Blue, road.Cost()...)
+
+			Expect(game.PlaceRoad(domain.Blue, road, time.Now())).To(Equal(domain.WrongPieceColorErr))
+		})
+	})
+
+	When("blue builds a road of four", func() {
+		BeforeEach(func() {
+			buildRoads(game, domain.Blue, blueRoads[:3]...)
+		})
+
+		It("road should be counted", func() {
+			blue, err := game.Player(domain.Blue)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blue.LongestRoad()).To(Equal(int64(4)))
+		})
+
+		It("blue should not get longest road", func() {
+			blue, err := game.Player(domain.Blue)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blue.LongestRoadOwner()).To(BeFalse())
+			Expect(blue.VictoryPoints()).To(Equal(int64(2)))
+		})
+	})
+
+	When("blue builds a road of five", func() {
+		BeforeEach(func() {
+			buildRoads(game, domain.Blue, blueRoads...)
+		})
+
+		It("blue should get longest road", func() {
+			Expect(game.LastEvent()).To(Equal(domain.LongestRoadOwnerChangedEvent{PlayerColor: domain.Blue, PreviousOwnerColor: domain.None}))
+
+			blue, err := game.Player(domain.Blue)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blue.LongestRoad()).To(Equal(int64(5)))
+			Expect(blue.LongestRoadOwner()).To(BeTrue())
+			Expect(blue.VictoryPoints()).To(Equal(int64(4)))
+		})
+
+		When("white builds roads to the blue one", func() {
+			BeforeEach(func() {
+				Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+				Expect(game.RollDice(domain.White, time.Now())).To(Succeed())
+
+				// white road goes from the (1,0,R) settlement to the middle of the blue road
+				buildRoads(game, domain.White,
+					grid.PathCoord{R: 2, C: 1, D: grid.N},
+					grid.PathCoord{R: 2, C: 2, D: grid.W},
+					grid.PathCoord{R: 2, C: 2, D: grid.N},
+				)
+			})
+
+			When("white breaks the road with a settlement", func() {
+				BeforeEach(func() {
+					settlement := domain.NewSettlement(domain.White, grid.IntersectionCoord{R: 2, C: 3, D: grid.L})
+					pickResources(game, domain.White, settlement.Cost()...)
+
+					Expect(game.PlaceSettlement(domain.White, settlement, time.Now())).To(Succeed())
+				})
+
+				It("blue should lose longest road", func() {
+					Expect(game.LastEvent()).To(Equal(domain.LongestRoadLostEvent{PlayerColor: domain.Blue}))
+
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.LongestRoad()).To(Equal(int64(3)))
+					Expect(blue.LongestRoadOwner()).To(BeFalse())
+					Expect(blue.VictoryPoints()).To(Equal(int64(2)))
+				})
+			})
+
+			When("white builds a road of five as well", func() {
+				BeforeEach(func() {
+					buildRoads(game, domain.White, grid.PathCoord{R: 2, C: 0, D: grid.N})
+				})
+
+				It("blue should keep longest road on a tie", func() {
+					white, err := game.Player(domain.White)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(white.LongestRoad()).To(Equal(int64(5)))
+					Expect(white.LongestRoadOwner()).To(BeFalse())
+
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.LongestRoadOwner()).To(BeTrue())
+					Expect(blue.VictoryPoints()).To(Equal(int64(4)))
+				})
+
+				When("white builds a road of six", func() {
+					BeforeEach(func() {
+						buildRoads(game, domain.White, grid.PathCoord{R: 2, C: 0, D: grid.W})
+					})
+
+					It("white should take longest road", func() {
+						Expect(game.LastEvent()).To(Equal(domain.LongestRoadOwnerChangedEvent{PlayerColor: domain.White, PreviousOwnerColor: domain.Blue}))
+
+						white, err := game.Player(domain.White)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(white.LongestRoad()).To(Equal(int64(6)))
+						Expect(white.LongestRoadOwner()).To(BeTrue())
+
+						blue, err := game.Player(domain.Blue)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(blue.LongestRoadOwner()).To(BeFalse())
+						Expect(blue.VictoryPoints()).To(Equal(int64(2)))
+					})
+				})
+			})
+		})
+	})
+})
+
+// buildRoads buys roads for the player
+func buildRoads(game *domain.Game, playerColor domain.Color, pathCoords ...grid.PathCoord) {
+	for _, pathCoord := range pathCoords {
+		road := domain.NewRoad(pathCoord, playerColor)
+		pickResources(game, playerColor, road.Cost()...)
+
+		Expect(game.PlaceRoad(playerColor, road, time.Now())).To(Succeed())
+	}
+}
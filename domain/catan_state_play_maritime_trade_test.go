@@ -0,0 +1,136 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play maritime trade", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+	})
+
+	It("ports should be on the board", func() {
+		path, exists := game.Board().Path(grid.PathCoord{R: 1, C: 0, D: grid.W})
+		Expect(exists).To(BeTrue())
+
+		port, hasPort := path.Port()
+		Expect(hasPort).To(BeTrue())
+		Expect(port).To(Equal(domain.NewResourcePort(domain.Ore)))
+
+		intersection, exists := game.Board().Intersection(grid.IntersectionCoord{R: 1, C: 0, D: grid.L})
+		Expect(exists).To(BeTrue())
+
+		port, hasPort = intersection.Port()
+		Expect(hasPort).To(BeTrue())
+		Expect(port).To(Equal(domain.NewResourcePort(domain.Ore)))
+	})
+
+	When("port is added not on the coast", func() {
+		It("should receive an error", func() {
+			Expect(game.Board().AddPort(grid.PathCoord{R: 2, C: 2, D: grid.N}, domain.NewGenericPort())).To(Equal(domain.PortMustBeOnCoastErr))
+		})
+	})
+
+	When("blue trades before rolling dice", func() {
+		It("should receive an error", func() {
+			Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardOre, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+		})
+	})
+
+	When("blue rolled dice", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		When("blue trades without enough resources", func() {
+			It("should receive an error", func() {
+				Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardOre, time.Now())).To(Equal(domain.NotEnoughResourcesErr))
+			})
+		})
+
+		When("blue trades the resource for the same resource", func() {
+			It("should receive an error", func() {
+				Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardWood, time.Now())).To(Equal(domain.BadTradeErr))
+			})
+		})
+
+		When("not current player trades", func() {
+			It("should receive an error", func() {
+				Expect(game.MaritimeTrade(domain.Red, domain.ResourceCardWood, domain.ResourceCardOre, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("blue trades four cards without ports", func() {
+			BeforeEach(func() {
+				pickResources(game, domain.Blue, domain.ResourceCardWood, domain.ResourceCardWood, domain.ResourceCardWood)
+
+				Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardOre, time.Now())).To(Succeed())
+			})
+
+			It("blue should get the card from the bank", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerTradedWithBankEvent{
+					PlayerColor:    domain.Blue,
+					GivenResources: []domain.ResourceCard{domain.ResourceCardWood, domain.ResourceCardWood, domain.ResourceCardWood, domain.ResourceCardWood},
+					TakenResources: []domain.ResourceCard{domain.ResourceCardOre},
+				}))
+
+				blue, err := game.Player(domain.Blue)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardOre, domain.ResourceCardBrick, domain.ResourceCardOre))
+			})
+		})
+
+		When("blue has a settlement on the generic port", func() {
+			BeforeEach(func() {
+				placeSettlement(game, domain.Blue, grid.IntersectionCoord{R: 0, C: 1, D: grid.L})
+			})
+
+			It("blue should trade three cards for one", func() {
+				Expect(domain.MaritimeTradeRate(game.Board(), domain.Blue, domain.ResourceCardWood)).To(Equal(int64(3)))
+				Expect(domain.MaritimeTradeRate(game.Board(), domain.White, domain.ResourceCardWood)).To(Equal(int64(4)))
+
+				pickResources(game, domain.Blue, domain.ResourceCardWood, domain.ResourceCardWood)
+
+				Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardSheep, time.Now())).To(Succeed())
+			})
+
+			When("blue has a settlement on the ore port too", func() {
+				BeforeEach(func() {
+					placeSettlement(game, domain.Blue, grid.IntersectionCoord{R: 1, C: 0, D: grid.L})
+				})
+
+				It("blue should trade two ore for one", func() {
+					Expect(domain.MaritimeTradeRate(game.Board(), domain.Blue, domain.ResourceCardOre)).To(Equal(int64(2)))
+					Expect(domain.MaritimeTradeRate(game.Board(), domain.Blue, domain.ResourceCardWood)).To(Equal(int64(3)))
+
+					pickResources(game, domain.Blue, domain.ResourceCardOre)
+
+					Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardOre, domain.ResourceCardWheat, time.Now())).To(Succeed())
+
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardWood, domain.ResourceCardBrick, domain.ResourceCardWheat))
+				})
+			})
+		})
+	})
+})
+
+// placeSettlement places the settlement skipping the rules checks
+func placeSettlement(game *domain.Game, playerColor domain.Color, intersectionCoord grid.IntersectionCoord) {
+	settlement := domain.NewSettlement(playerColor, intersectionCoord)
+	pickResources(game, playerColor, settlement.Cost()...)
+
+	game.Apply(domain.NewEventDescriptor(game.Id(), domain.PlayerPlacedSettlementEvent{
+		PlayerColor: playerColor,
+		Settlement:  settlement,
+	}, nil, game.Version(), time.Now()), true)
+}
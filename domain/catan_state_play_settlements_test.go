@@ -0,0 +1,82 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play settlements", func() {
+	var game *domain.Game
+
+	placeBlueSettlement := func(intersectionCoord grid.IntersectionCoord) error {
+		settlement := domain.NewSettlement(domain.Blue, intersectionCoord)
+		pickResources(game, domain.Blue, settlement.Cost()...)
+
+		return game.PlaceSettlement(domain.Blue, settlement, time.Now())
+	}
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("blue places a settlement away from his roads", func() {
+		It("should receive an error", func() {
+			Expect(placeBlueSettlement(grid.IntersectionCoord{R: 2, C: 3, D: grid.L})).To(Equal(domain.SettlementIsNotConnectedErr))
+		})
+	})
+
+	When("blue places a red settlement", func() {
+		It("should receive an error", func() {
+			settlement := domain.NewSettlement(domain.Red, grid.IntersectionCoord{R: 2, C: 3, D: grid.L})
+			pickResources(game, domain.Blue, settlement.Cost()...)
+
+			Expect(game.PlaceSettlement(domain.Blue, settlement, time.Now())).To(Equal(domain.WrongPieceColorErr))
+
+			intersection, exists := game.Board().Intersection(grid.IntersectionCoord{R: 2, C: 3, D: grid.L})
+			Expect(exists).To(BeTrue())
+			Expect(intersection.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	When("blue places a settlement next to his own settlement", func() {
+		It("should receive an error", func() {
+			Expect(placeBlueSettlement(grid.IntersectionCoord{R: 3, C: 4, D: grid.L})).To(Equal(domain.CommandIsForbiddenErr))
+		})
+	})
+
+	When("blue places a settlement on the occupied intersection", func() {
+		It("should receive an error", func() {
+			Expect(placeBlueSettlement(grid.IntersectionCoord{R: 3, C: 3, D: grid.R})).To(Equal(domain.IntersectionAlreadyHasObjectErr))
+		})
+	})
+
+	When("blue places a settlement at the end of his road", func() {
+		BeforeEach(func() {
+			buildRoads(game, domain.Blue, grid.PathCoord{R: 3, C: 3, D: grid.N}, grid.PathCoord{R: 2, C: 2, D: grid.E})
+
+			Expect(placeBlueSettlement(grid.IntersectionCoord{R: 2, C: 3, D: grid.L})).To(Succeed())
+		})
+
+		It("settlement should be placed", func() {
+			intersection, exists := game.Board().Intersection(grid.IntersectionCoord{R: 2, C: 3, D: grid.L})
+			Expect(exists).To(BeTrue())
+			Expect(intersection.Building()).To(Equal(domain.NewSettlement(domain.Blue, grid.IntersectionCoord{R: 2, C: 3, D: grid.L})))
+		})
+
+		It("blue should pay for the settlement", func() {
+			blue, err := game.Player(domain.Blue)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardWood, domain.ResourceCardOre, domain.ResourceCardBrick))
+			Expect(blue.VictoryPoints()).To(Equal(int64(3)))
+
+			Expect(game.BankResources()[domain.ResourceCardSheep]).To(Equal(int64(19)))
+		})
+	})
+})
@@ -1,12 +1,15 @@
 package domain_test
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/rannoch/catan/domain"
 	. "github.com/rannoch/catan/domain/games/catan_championship_premium_13_BUGGED_Semi_Final"
+	"github.com/rannoch/catan/domain/games/catan_rule_example"
+	"github.com/rannoch/catan/grid"
 )
 
 var _ = Describe("Catan state play", func() {
@@ -68,9 +71,332 @@ var _ = Describe("Catan state play", func() {
 
 	When("black rolls a dice", func() {
 
-
 		It("", func() {
 
 		})
 	})
 })
+
+type fixedDiceRoller struct {
+	roll domain.Roll
+}
+
+func (f *fixedDiceRoller) Roll() domain.Roll {
+	return f.roll
+}
+
+// firstResourcePicker always steals the first card
+type firstResourcePicker struct{}
+
+func (firstResourcePicker) Pick(resources []domain.ResourceCard) domain.ResourceCard {
+	return resources[0]
+}
+
+// replayRuleExample replays the example game from the rules, selection events are applied before the game is started
+func replayRuleExample(selectionEvents ...interface{}) *domain.Game {
+	game := &domain.Game{}
+
+	for _, event := range catan_rule_example.Events() {
+		if _, ok := event.(domain.GameStartedEvent); ok {
+			for _, selectionEvent := range selectionEvents {
+				game.Apply(domain.NewEventDescriptor(game.Id(), selectionEvent, nil, game.Version(), time.Now()), true)
+			}
+		}
+
+		game.Apply(domain.NewEventDescriptor(game.Id(), event, nil, game.Version(), time.Now()), true)
+	}
+
+	return game
+}
+
+var _ = Describe("Catan state play rolling dice", func() {
+	var (
+		game       *domain.Game
+		diceRoller *fixedDiceRoller
+	)
+
+	BeforeEach(func() {
+		diceRoller = &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.ResourcePickerSelectedEvent{ResourcePicker: firstResourcePicker{}})
+	})
+
+	AfterEach(func() {
+		if CurrentGinkgoTestDescription().Failed {
+			fmt.Fprint(GinkgoWriter, domain.RenderBoard(game.Board()))
+		}
+	})
+
+	When("not current player rolls a dice", func() {
+		It("should receive an error", func() {
+			Expect(game.RollDice(domain.Red, time.Now())).To(Equal(domain.WrongTurnErr))
+		})
+	})
+
+	When("blue rolls 6", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("roll should be in the history", func() {
+			Expect(game.RollHistory()).To(Equal([]domain.Roll{diceRoller.roll}))
+		})
+
+		It("players with buildings next to 6 should pick resources", func() {
+			expectedResources := map[domain.Color][]domain.ResourceCard{
+				domain.Blue:   {domain.ResourceCardWood, domain.ResourceCardOre, domain.ResourceCardBrick},
+				domain.White:  {domain.ResourceCardWheat, domain.ResourceCardBrick, domain.ResourceCardWood, domain.ResourceCardBrick},
+				domain.Red:    {domain.ResourceCardWheat, domain.ResourceCardWood, domain.ResourceCardWood, domain.ResourceCardBrick},
+				domain.Yellow: {domain.ResourceCardOre, domain.ResourceCardWheat, domain.ResourceCardWheat, domain.ResourceCardWheat},
+			}
+
+			for color, resources := range expectedResources {
+				player, err := game.Player(color)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(player.Resources()).To(Equal(resources))
+			}
+		})
+
+		It("picked resources events should follow the turn order", func() {
+			var pickedBy []domain.Color
+
+			for _, eventMessage := range game.Changes() {
+				if event, ok := eventMessage.Event().(domain.PlayerPickedResourcesEvent); ok {
+					pickedBy = append(pickedBy, event.PlayerColor)
+				}
+			}
+
+			Expect(pickedBy[len(pickedBy)-3:]).To(Equal([]domain.Color{domain.White, domain.Red, domain.Yellow}))
+		})
+
+		When("he tries to roll again", func() {
+			It("should receive an error", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+
+		When("he tries to place the robber", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+	})
+
+	It("robber should start in the desert", func() {
+		robber, exists := game.Board().Robber()
+		Expect(exists).To(BeTrue())
+		Expect(robber).To(Equal(grid.HexCoord{R: 2, C: 2}))
+	})
+
+	When("blue rolls 7", func() {
+		BeforeEach(func() {
+			diceRoller.roll = domain.NewRoll(domain.D6Roll3, domain.D6Roll4)
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("nobody should pick resources", func() {
+			Expect(game.LastEvent()).To(Equal(domain.PlayerRolledDiceEvent{Roll: diceRoller.roll}))
+		})
+
+		When("not current player places the robber", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceRobber(domain.Red, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("he leaves the robber in the desert", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 2, C: 2}, time.Now())).To(Equal(domain.RobberMustBeMovedErr))
+			})
+		})
+
+		When("he places the robber outside of the board", func() {
+			It("should receive an error", func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 7, C: 7}, time.Now())).To(Equal(domain.BadHexCoordErr))
+			})
+		})
+
+		When("he places the robber to the brick 6", func() {
+			BeforeEach(func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Succeed())
+			})
+
+			It("robber should be moved", func() {
+				robber, exists := game.Board().Robber()
+				Expect(exists).To(BeTrue())
+				Expect(robber).To(Equal(grid.HexCoord{R: 1, C: 1}))
+				Expect(game.LastEvent()).To(Equal(domain.PlayerMovedRobberEvent{PlayerColor: domain.Blue, HexCoord: grid.HexCoord{R: 1, C: 1}}))
+			})
+
+			It("brick 6 should not produce", func() {
+				hexes := game.Board().HexesByNumberToken(6)
+				Expect(hexes).To(HaveLen(1))
+				Expect(hexes[0].Coord).To(Equal(grid.HexCoord{R: 4, C: 3}))
+			})
+
+			When("he tries to place the robber again", func() {
+				It("should receive an error", func() {
+					Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 2, C: 2}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				})
+			})
+
+			When("he robs yellow without buildings next to the robber", func() {
+				It("should receive an error", func() {
+					Expect(game.RobPlayer(domain.Blue, domain.Yellow, time.Now())).To(Equal(domain.PlayerCannotBeRobbedErr))
+				})
+			})
+
+			When("not current player robs", func() {
+				It("should receive an error", func() {
+					Expect(game.RobPlayer(domain.Red, domain.White, time.Now())).To(Equal(domain.WrongTurnErr))
+				})
+			})
+
+			When("he robs white", func() {
+				BeforeEach(func() {
+					Expect(game.RobPlayer(domain.Blue, domain.White, time.Now())).To(Succeed())
+				})
+
+				It("the card should be moved from white to blue", func() {
+					white, err := game.Player(domain.White)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(white.Resources()).To(Equal([]domain.ResourceCard{domain.ResourceCardBrick, domain.ResourceCardWood}))
+
+					blue, err := game.Player(domain.Blue)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(blue.Resources()).To(Equal([]domain.ResourceCard{domain.ResourceCardWood, domain.ResourceCardOre, domain.ResourceCardBrick, domain.ResourceCardWheat}))
+				})
+
+				It("he cannot rob again", func() {
+					Expect(game.RobPlayer(domain.Blue, domain.Red, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				})
+			})
+		})
+
+		When("he places the robber next to yellow only", func() {
+			BeforeEach(func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 4, C: 3}, time.Now())).To(Succeed())
+			})
+
+			It("yellow should be robbed without selection", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerWasRobbedByPlayerEvent{
+					RobbingPlayerColor: domain.Blue,
+					RobbedPlayerColor:  domain.Yellow,
+					DumpedResources:    []domain.ResourceCard{domain.ResourceCardOre},
+				}))
+				Expect(game.RobPlayer(domain.Blue, domain.Yellow, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+
+		When("he places the robber next to his own settlement only", func() {
+			BeforeEach(func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 4, C: 4}, time.Now())).To(Succeed())
+			})
+
+			It("nobody should be robbed", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerMovedRobberEvent{PlayerColor: domain.Blue, HexCoord: grid.HexCoord{R: 4, C: 4}}))
+				Expect(game.RobPlayer(domain.Blue, domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+	})
+
+	When("blue rolls 7 while red and white hold too many resources", func() {
+		BeforeEach(func() {
+			pickResources(game, domain.Red, domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep, domain.ResourceCardBrick, domain.ResourceCardBrick)
+			pickResources(game, domain.White, domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep, domain.ResourceCardWheat)
+
+			diceRoller.roll = domain.NewRoll(domain.D6Roll3, domain.D6Roll4)
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("robber cannot be placed until they discard", func() {
+			Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+		})
+
+		When("player with few resources discards", func() {
+			It("should receive an error", func() {
+				Expect(game.DiscardResources(domain.Blue, []domain.ResourceCard{domain.ResourceCardWood}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+
+		When("red discards less than half of his resources", func() {
+			It("should receive an error", func() {
+				Expect(game.DiscardResources(domain.Red, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep}, time.Now())).
+					To(Equal(domain.WrongNumberOfResourcesToDiscardErr))
+			})
+		})
+
+		When("red discards resources he doesn't have", func() {
+			It("should receive an error", func() {
+				Expect(game.DiscardResources(domain.Red, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardOre}, time.Now())).
+					To(Equal(domain.NotEnoughResourcesErr))
+			})
+		})
+
+		When("red discards", func() {
+			BeforeEach(func() {
+				Expect(game.DiscardResources(domain.Red, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardWood, domain.ResourceCardWood}, time.Now())).To(Succeed())
+			})
+
+			It("red should keep the rest of the resources", func() {
+				player, err := game.Player(domain.Red)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(player.Resources()).To(Equal([]domain.ResourceCard{domain.ResourceCardWheat, domain.ResourceCardSheep, domain.ResourceCardSheep, domain.ResourceCardBrick, domain.ResourceCardBrick}))
+			})
+
+			It("discard should be recorded", func() {
+				Expect(game.LastEvent()).To(Equal(domain.PlayerWasRobbedByRobberEvent{
+					RobbedPlayerColor: domain.Red,
+					DumpedResources:   []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardWood, domain.ResourceCardWood},
+				}))
+			})
+
+			It("robber still cannot be placed", func() {
+				Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			It("red cannot discard twice", func() {
+				Expect(game.DiscardResources(domain.Red, []domain.ResourceCard{domain.ResourceCardSheep, domain.ResourceCardSheep}, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			When("white discards", func() {
+				BeforeEach(func() {
+					Expect(game.DiscardResources(domain.White, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep}, time.Now())).To(Succeed())
+				})
+
+				It("blue should place the robber", func() {
+					Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Succeed())
+				})
+			})
+		})
+	})
+
+	When("hand limit is raised to 8 and blue rolls 7", func() {
+		BeforeEach(func() {
+			rules := domain.DefaultGameRules()
+			rules.HandLimit = 8
+
+			game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.GameRulesSelectedEvent{GameRules: rules})
+
+			pickResources(game, domain.Red, domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep, domain.ResourceCardBrick, domain.ResourceCardBrick)
+			pickResources(game, domain.White, domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep, domain.ResourceCardWheat)
+
+			diceRoller.roll = domain.NewRoll(domain.D6Roll3, domain.D6Roll4)
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		It("white with 8 resources should not discard", func() {
+			Expect(game.DiscardResources(domain.White, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardSheep, domain.ResourceCardSheep}, time.Now())).
+				To(Equal(domain.CommandIsForbiddenErr))
+			Expect(game.DiscardResources(domain.Red, []domain.ResourceCard{domain.ResourceCardOre, domain.ResourceCardOre, domain.ResourceCardWood, domain.ResourceCardWood}, time.Now())).To(Succeed())
+			Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Succeed())
+		})
+	})
+})
+
+// pickResources gives resources to the player as if they were produced
+func pickResources(game *domain.Game, playerColor domain.Color, resources ...domain.ResourceCard) {
+	game.Apply(domain.NewEventDescriptor(game.Id(), domain.PlayerPickedResourcesEvent{
+		PlayerColor:     playerColor,
+		PickedResources: resources,
+	}, nil, game.Version(), time.Now()), true)
+}
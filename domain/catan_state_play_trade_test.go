@@ -0,0 +1,181 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play domestic trade", func() {
+	var game *domain.Game
+
+	woodForWheat := func(targetColors ...domain.Color) error {
+		return game.ProposeTrade(domain.Blue, []domain.ResourceCard{domain.ResourceCardWood}, []domain.ResourceCard{domain.ResourceCardWheat}, targetColors, time.Now())
+	}
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+	})
+
+	When("blue proposes a trade before rolling dice", func() {
+		It("should receive an error", func() {
+			Expect(woodForWheat()).To(Equal(domain.CommandIsForbiddenErr))
+		})
+	})
+
+	When("blue rolled dice", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		When("not current player proposes a trade", func() {
+			It("should receive an error", func() {
+				Expect(game.ProposeTrade(domain.White, []domain.ResourceCard{domain.ResourceCardWood}, []domain.ResourceCard{domain.ResourceCardOre}, nil, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("blue proposes the resource for the same resource", func() {
+			It("should receive an error", func() {
+				Expect(game.ProposeTrade(domain.Blue, []domain.ResourceCard{domain.ResourceCardWood}, []domain.ResourceCard{domain.ResourceCardWood}, nil, time.Now())).To(Equal(domain.BadTradeErr))
+			})
+		})
+
+		When("blue proposes resources he doesn't have", func() {
+			It("should receive an error", func() {
+				Expect(game.ProposeTrade(domain.Blue, []domain.ResourceCard{domain.ResourceCardSheep}, []domain.ResourceCard{domain.ResourceCardWheat}, nil, time.Now())).To(Equal(domain.NotEnoughResourcesErr))
+			})
+		})
+
+		When("blue proposes wood for wheat to everybody", func() {
+			BeforeEach(func() {
+				Expect(woodForWheat()).To(Succeed())
+			})
+
+			It("offer should be opened", func() {
+				Expect(game.LastEvent()).To(Equal(domain.TradeOfferedEvent{
+					TradeOfferId:   1,
+					PlayerColor:    domain.Blue,
+					GivenResources: []domain.ResourceCard{domain.ResourceCardWood},
+					TakenResources: []domain.ResourceCard{domain.ResourceCardWheat},
+				}))
+				Expect(game.TradeOffers()).To(HaveLen(1))
+			})
+
+			When("blue accepts his own offer", func() {
+				It("should receive an error", func() {
+					Expect(game.AcceptTradeOffer(domain.Blue, 1, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				})
+			})
+
+			When("blue confirms the offer nobody accepted", func() {
+				It("should receive an error", func() {
+					Expect(game.ConfirmTrade(domain.Blue, 1, domain.White, time.Now())).To(Equal(domain.TradeOfferIsNotAcceptedErr))
+				})
+			})
+
+			When("white accepts and red rejects", func() {
+				BeforeEach(func() {
+					Expect(game.AcceptTradeOffer(domain.White, 1, time.Now())).To(Succeed())
+					Expect(game.RejectTradeOffer(domain.Red, 1, time.Now())).To(Succeed())
+				})
+
+				It("red cannot answer twice", func() {
+					Expect(game.AcceptTradeOffer(domain.Red, 1, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				})
+
+				It("blue cannot confirm the trade with red", func() {
+					Expect(game.ConfirmTrade(domain.Blue, 1, domain.Red, time.Now())).To(Equal(domain.TradeOfferIsNotAcceptedErr))
+				})
+
+				When("blue confirms the trade with white", func() {
+					BeforeEach(func() {
+						Expect(game.ConfirmTrade(domain.Blue, 1, domain.White, time.Now())).To(Succeed())
+					})
+
+					It("resources should be exchanged", func() {
+						blue, err := game.Player(domain.Blue)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardOre, domain.ResourceCardBrick, domain.ResourceCardWheat))
+
+						white, err := game.Player(domain.White)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(white.Resources()).To(ConsistOf(domain.ResourceCardBrick, domain.ResourceCardBrick, domain.ResourceCardWood, domain.ResourceCardWood))
+					})
+
+					It("offer should be closed", func() {
+						Expect(game.TradeOffers()).To(BeEmpty())
+						Expect(game.ConfirmTrade(domain.Blue, 1, domain.White, time.Now())).To(Equal(domain.TradeOfferNotFoundErr))
+					})
+				})
+			})
+
+			When("blue builds a road with the offered wood", func() {
+				BeforeEach(func() {
+					Expect(game.PlaceRoad(domain.Blue, domain.NewRoad(grid.PathCoord{R: 3, C: 3, D: grid.N}, domain.Blue), time.Now())).To(Succeed())
+				})
+
+				It("offer should be invalidated", func() {
+					Expect(game.LastEvent()).To(Equal(domain.TradeOfferInvalidatedEvent{TradeOfferId: 1}))
+					Expect(game.TradeOffers()).To(BeEmpty())
+				})
+			})
+
+			When("blue finishes his turn", func() {
+				It("offer should be closed", func() {
+					startTurn(game, domain.White)
+
+					Expect(game.TradeOffers()).To(BeEmpty())
+				})
+			})
+		})
+
+		When("blue proposes wood for wheat to yellow", func() {
+			BeforeEach(func() {
+				Expect(woodForWheat(domain.Yellow)).To(Succeed())
+			})
+
+			It("white cannot accept it", func() {
+				Expect(game.AcceptTradeOffer(domain.White, 1, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+
+			When("yellow counters with ore for wood", func() {
+				BeforeEach(func() {
+					Expect(game.CounterTradeOffer(domain.Yellow, 1, []domain.ResourceCard{domain.ResourceCardOre}, []domain.ResourceCard{domain.ResourceCardWood}, time.Now())).To(Succeed())
+				})
+
+				It("counter offer should be accepted by yellow", func() {
+					counterTradeOffer, exists := game.TradeOffer(2)
+					Expect(exists).To(BeTrue())
+					Expect(counterTradeOffer.PlayerColor()).To(Equal(domain.Blue))
+					Expect(counterTradeOffer.GivenResources()).To(Equal([]domain.ResourceCard{domain.ResourceCardWood}))
+					Expect(counterTradeOffer.TakenResources()).To(Equal([]domain.ResourceCard{domain.ResourceCardOre}))
+					Expect(counterTradeOffer.IsAcceptedBy(domain.Yellow)).To(BeTrue())
+				})
+
+				When("blue confirms the counter offer", func() {
+					BeforeEach(func() {
+						Expect(game.ConfirmTrade(domain.Blue, 2, domain.Yellow, time.Now())).To(Succeed())
+					})
+
+					It("resources should be exchanged", func() {
+						blue, err := game.Player(domain.Blue)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(blue.Resources()).To(ConsistOf(domain.ResourceCardOre, domain.ResourceCardBrick, domain.ResourceCardOre))
+
+						yellow, err := game.Player(domain.Yellow)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(yellow.Resources()).To(ConsistOf(domain.ResourceCardWheat, domain.ResourceCardWheat, domain.ResourceCardWheat, domain.ResourceCardWood))
+					})
+
+					It("the original offer should be invalidated", func() {
+						Expect(game.LastEvent()).To(Equal(domain.TradeOfferInvalidatedEvent{TradeOfferId: 1}))
+					})
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+)
+
+var _ = Describe("Catan state play turns", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+	})
+
+	When("blue ends his turn before rolling dice", func() {
+		It("should receive an error", func() {
+			Expect(game.EndTurn(domain.Blue, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+		})
+	})
+
+	When("blue rolled dice", func() {
+		BeforeEach(func() {
+			Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+		})
+
+		When("not current player ends the turn", func() {
+			It("should receive an error", func() {
+				Expect(game.EndTurn(domain.White, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("blue ends his turn", func() {
+			BeforeEach(func() {
+				Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+			})
+
+			It("white should start his turn", func() {
+				events := changedEvents(game)
+				Expect(events[len(events)-2:]).To(Equal([]interface{}{
+					domain.PlayerFinishedHisTurnEvent{PlayerColor: domain.Blue},
+					domain.PlayerStartedHisTurnEvent{PlayerColor: domain.White},
+				}))
+				Expect(game.CurrentTurn()).To(Equal(domain.White))
+			})
+
+			It("white should roll dice first", func() {
+				Expect(game.EndTurn(domain.White, time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+				Expect(game.RollDice(domain.White, time.Now())).To(Succeed())
+			})
+
+			It("blue cannot roll dice anymore", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.WrongTurnErr))
+			})
+		})
+
+		When("every player ends his turn", func() {
+			BeforeEach(func() {
+				Expect(game.EndTurn(domain.Blue, time.Now())).To(Succeed())
+
+				for _, color := range []domain.Color{domain.White, domain.Red, domain.Yellow} {
+					Expect(game.CurrentTurn()).To(Equal(color))
+					Expect(game.RollDice(color, time.Now())).To(Succeed())
+					Expect(game.EndTurn(color, time.Now())).To(Succeed())
+				}
+			})
+
+			It("blue should start his turn again", func() {
+				Expect(game.CurrentTurn()).To(Equal(domain.Blue))
+			})
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state play victory", func() {
+	var game *domain.Game
+
+	placeCity := func(intersectionCoord grid.IntersectionCoord) {
+		city := domain.NewCity(domain.Blue, intersectionCoord)
+		pickResources(game, domain.Blue, city.Cost()...)
+
+		Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		diceRoller := &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller})
+
+		// six hidden victory points
+		giveDevelopmentCards(game, domain.Blue,
+			domain.DevelopmentCardVictoryPoint, domain.DevelopmentCardVictoryPoint, domain.DevelopmentCardVictoryPoint,
+			domain.DevelopmentCardVictoryPoint, domain.DevelopmentCardVictoryPoint, domain.DevelopmentCardVictoryPoint,
+		)
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("blue has nine victory points", func() {
+		BeforeEach(func() {
+			placeCity(grid.IntersectionCoord{R: 3, C: 3, D: grid.R})
+		})
+
+		It("game should go on", func() {
+			blue, err := game.Player(domain.Blue)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blue.VictoryPoints()).To(Equal(int64(3)))
+			Expect(blue.TotalVictoryPoints()).To(Equal(int64(9)))
+
+			Expect(game.Winner()).To(Equal(domain.None))
+		})
+
+		When("blue reaches ten victory points", func() {
+			BeforeEach(func() {
+				placeCity(grid.IntersectionCoord{R: 3, C: 1, D: grid.R})
+			})
+
+			It("blue should win", func() {
+				Expect(game.LastEvent()).To(Equal(domain.GameFinishedEvent{
+					WinnerColor: domain.Blue,
+					FinalScores: map[domain.Color]int64{
+						domain.Blue:   10,
+						domain.White:  2,
+						domain.Red:    2,
+						domain.Yellow: 2,
+					},
+				}))
+
+				Expect(game.Winner()).To(Equal(domain.Blue))
+				Expect(game.FinalScores()[domain.Blue]).To(Equal(int64(10)))
+			})
+
+			It("every command should be rejected", func() {
+				Expect(game.RollDice(domain.Blue, time.Now())).To(Equal(domain.GameAlreadyFinishedErr))
+				Expect(game.BuyDevelopmentCard(domain.Blue, time.Now())).To(Equal(domain.GameAlreadyFinishedErr))
+				Expect(game.EndTurn(domain.Blue, time.Now())).To(Equal(domain.GameAlreadyFinishedErr))
+				Expect(game.CurrentTurn()).To(Equal(domain.None))
+			})
+		})
+	})
+})
@@ -0,0 +1,158 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rannoch/catan/domain"
+	"github.com/rannoch/catan/grid"
+)
+
+var _ = Describe("Catan state new game rules", func() {
+	var game *domain.Game
+
+	BeforeEach(func() {
+		game = domain.NewGame("test_id", time.Now())
+
+		Expect(game.SetBoardGenerator(testBoardGenerator{}, time.Now())).To(Succeed())
+		Expect(game.SetPlayersShuffler(simplePlayersShuffler{}, time.Now())).To(Succeed())
+	})
+
+	It("default rules should be used", func() {
+		Expect(game.Rules()).To(Equal(domain.DefaultGameRules()))
+	})
+
+	When("rules with zero hand limit are selected", func() {
+		It("should receive an error", func() {
+			rules := domain.DefaultGameRules()
+			rules.HandLimit = 0
+
+			Expect(game.SetGameRules(rules, time.Now())).To(Equal(domain.BadHandLimitErr))
+		})
+	})
+
+	When("rules with more min players than max players are selected", func() {
+		It("should receive an error", func() {
+			rules := domain.DefaultGameRules()
+			rules.MinPlayers = 4
+			rules.MaxPlayers = 3
+
+			Expect(game.SetGameRules(rules, time.Now())).To(Equal(domain.BadGameRulesErr))
+		})
+	})
+
+	When("the game is for two or three players with more roads", func() {
+		BeforeEach(func() {
+			rules := domain.DefaultGameRules()
+			rules.MinPlayers = 2
+			rules.MaxPlayers = 3
+			rules.Roads = 20
+
+			Expect(game.SetGameRules(rules, time.Now())).To(Succeed())
+
+			Expect(game.AddPlayer(domain.NewPlayer(domain.Blue, "baska"), time.Now())).To(Succeed())
+			Expect(game.AddPlayer(domain.NewPlayer(domain.White, "bot"), time.Now())).To(Succeed())
+			Expect(game.AddPlayer(domain.NewPlayer(domain.Red, "masha"), time.Now())).To(Succeed())
+		})
+
+		It("the fourth player cannot join", func() {
+			Expect(game.AddPlayer(domain.NewPlayer(domain.Yellow, "vasya"), time.Now())).To(Equal(domain.GameIsFullErr))
+		})
+
+		When("the game is started without a dice roller", func() {
+			It("should receive an error", func() {
+				Expect(game.StartGame(time.Now())).To(Equal(domain.DiceRollerIsNotSelectedErr))
+			})
+		})
+
+		When("the game is started", func() {
+			BeforeEach(func() {
+				Expect(game.SetDiceRoller(domain.NewRandomDiceRoller(1), time.Now())).To(Succeed())
+				Expect(game.StartGame(time.Now())).To(Succeed())
+			})
+
+			It("players should get pieces by the rules", func() {
+				for _, player := range game.Players() {
+					Expect(player.AvailableRoads()).To(Equal(int64(20)))
+					Expect(player.AvailableSettlements()).To(Equal(int64(domain.DefaultSettlements)))
+				}
+			})
+
+			It("rules cannot be changed", func() {
+				Expect(game.SetGameRules(domain.DefaultGameRules(), time.Now())).To(Equal(domain.CommandIsForbiddenErr))
+			})
+		})
+	})
+
+	When("the game is started with two players by default rules", func() {
+		It("should receive an error", func() {
+			Expect(game.AddPlayer(domain.NewPlayer(domain.Blue, "baska"), time.Now())).To(Succeed())
+			Expect(game.AddPlayer(domain.NewPlayer(domain.White, "bot"), time.Now())).To(Succeed())
+
+			Expect(game.StartGame(time.Now())).To(Equal(domain.NotEnoughPlayersErr))
+		})
+	})
+})
+
+var _ = Describe("Catan state play game rules", func() {
+	var (
+		game       *domain.Game
+		diceRoller *fixedDiceRoller
+		rules      domain.GameRules
+	)
+
+	BeforeEach(func() {
+		diceRoller = &fixedDiceRoller{roll: domain.NewRoll(domain.D6Roll3, domain.D6Roll3)}
+		rules = domain.DefaultGameRules()
+	})
+
+	JustBeforeEach(func() {
+		game = replayRuleExample(domain.DiceRollerSelected{DiceRoller: diceRoller}, domain.GameRulesSelectedEvent{GameRules: rules})
+
+		Expect(game.RollDice(domain.Blue, time.Now())).To(Succeed())
+	})
+
+	When("three victory points are enough to win", func() {
+		BeforeEach(func() {
+			rules.VictoryPointsToWin = 3
+		})
+
+		It("blue should win with the first city", func() {
+			city := domain.NewCity(domain.Blue, grid.IntersectionCoord{R: 3, C: 3, D: grid.R})
+			pickResources(game, domain.Blue, city.Cost()...)
+
+			Expect(game.PlaceCity(domain.Blue, city, time.Now())).To(Succeed())
+			Expect(game.Winner()).To(Equal(domain.Blue))
+		})
+	})
+
+	When("trades are not allowed", func() {
+		BeforeEach(func() {
+			rules.DomesticTradeAllowed = false
+			rules.MaritimeTradeAllowed = false
+		})
+
+		It("blue cannot trade", func() {
+			Expect(game.ProposeTrade(domain.Blue, []domain.ResourceCard{domain.ResourceCardWood}, []domain.ResourceCard{domain.ResourceCardWheat}, nil, time.Now())).To(Equal(domain.TradeIsNotAllowedErr))
+
+			pickResources(game, domain.Blue, domain.Wood.GetResourceCard(4)...)
+			Expect(game.MaritimeTrade(domain.Blue, domain.ResourceCardWood, domain.ResourceCardSheep, time.Now())).To(Equal(domain.TradeIsNotAllowedErr))
+		})
+	})
+
+	When("the robber is friendly to players with two victory points", func() {
+		BeforeEach(func() {
+			rules.FriendlyRobberVictoryPoints = 2
+			diceRoller.roll = domain.NewRoll(domain.D6Roll3, domain.D6Roll4)
+		})
+
+		It("blue cannot place the robber next to white and red", func() {
+			Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 1, C: 1}, time.Now())).To(Equal(domain.FriendlyRobberErr))
+		})
+
+		It("blue can place the robber next to his own settlement", func() {
+			Expect(game.PlaceRobber(domain.Blue, grid.HexCoord{R: 4, C: 4}, time.Now())).To(Succeed())
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math/rand"
 	"time"
 
 	"github.com/rannoch/catan/grid"
@@ -10,31 +11,105 @@ type PlayersShuffler interface {
 	Shuffle(playerColors []Color) []Color
 }
 
-type RandomPlayersShuffler struct{}
+// RandomPlayersShuffler
+// shuffles players with the seed, the same seed always gives the same turn order
+type RandomPlayersShuffler struct {
+	seed   int64
+	crypto bool
+}
 
-func NewRandomPlayersShuffler() RandomPlayersShuffler {
-	return RandomPlayersShuffler{}
+func NewRandomPlayersShuffler(seed int64) RandomPlayersShuffler {
+	return RandomPlayersShuffler{seed: seed}
 }
 
-// todo true random
-func (RandomPlayersShuffler) Shuffle(playerColors []Color) []Color {
-	return playerColors
+// NewCryptoRandomPlayersShuffler creates the shuffler for competitive games, the turn order can't be predicted
+func NewCryptoRandomPlayersShuffler() RandomPlayersShuffler {
+	return RandomPlayersShuffler{crypto: true}
 }
 
-type BoardGenerator interface {
-	GenerateBoard() Board
+func (shuffler RandomPlayersShuffler) Shuffle(playerColors []Color) []Color {
+	shuffled := make([]Color, len(playerColors))
+	copy(shuffled, playerColors)
+
+	newRand(shuffler.seed, shuffler.crypto).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled
+}
+
+func (shuffler RandomPlayersShuffler) Seed() (int64, bool) {
+	return shuffler.seed, !shuffler.crypto
+}
+
+// ResourcePicker picks a card to steal from the robbed player
+type ResourcePicker interface {
+	Pick(resources []ResourceCard) ResourceCard
+}
+
+// RandomResourcePicker
+// picks cards with the seed, the same seed always picks the same cards
+type RandomResourcePicker struct {
+	seed   int64
+	crypto bool
+	random *rand.Rand
+}
+
+func NewRandomResourcePicker(seed int64) *RandomResourcePicker {
+	return &RandomResourcePicker{seed: seed, random: newRand(seed, false)}
+}
+
+// NewCryptoRandomResourcePicker creates the picker for competitive games, stolen cards can't be predicted
+func NewCryptoRandomResourcePicker() *RandomResourcePicker {
+	return &RandomResourcePicker{crypto: true, random: newRand(0, true)}
+}
+
+func (picker *RandomResourcePicker) Pick(resources []ResourceCard) ResourceCard {
+	return resources[picker.random.Intn(len(resources))]
+}
+
+func (picker *RandomResourcePicker) Seed() (int64, bool) {
+	return picker.seed, !picker.crypto
+}
+
+type DevelopmentCardsShuffler interface {
+	Shuffle(developmentCards []DevelopmentCard) []DevelopmentCard
+}
+
+// RandomDevelopmentCardsShuffler
+// shuffles development cards with the seed, the same seed always gives the same deck
+type RandomDevelopmentCardsShuffler struct {
+	seed   int64
+	crypto bool
+	random *rand.Rand
+}
+
+func NewRandomDevelopmentCardsShuffler(seed int64) *RandomDevelopmentCardsShuffler {
+	return &RandomDevelopmentCardsShuffler{seed: seed, random: newRand(seed, false)}
+}
+
+// NewCryptoRandomDevelopmentCardsShuffler creates the shuffler for competitive games, the deck can't be predicted
+func NewCryptoRandomDevelopmentCardsShuffler() *RandomDevelopmentCardsShuffler {
+	return &RandomDevelopmentCardsShuffler{crypto: true, random: newRand(0, true)}
 }
 
-type RandomBoardGenerator struct{}
+func (shuffler *RandomDevelopmentCardsShuffler) Shuffle(developmentCards []DevelopmentCard) []DevelopmentCard {
+	shuffled := make([]DevelopmentCard, len(developmentCards))
+	copy(shuffled, developmentCards)
+
+	shuffler.random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
 
-func NewRandomBoardGenerator() RandomBoardGenerator {
-	return RandomBoardGenerator{}
+	return shuffled
 }
 
-func (RandomBoardGenerator) GenerateBoard() Board {
-	return NewBoardWithOffsetCoord(
-		map[grid.HexCoord]Hex{},
-	) // todo
+func (shuffler *RandomDevelopmentCardsShuffler) Seed() (int64, bool) {
+	return shuffler.seed, !shuffler.crypto
+}
+
+type BoardGenerator interface {
+	GenerateBoard() Board
 }
 
 type StartGameCommand struct {
@@ -42,7 +117,7 @@ type StartGameCommand struct {
 	players         []Player
 	playersShuffler PlayersShuffler
 	boardGenerator  BoardGenerator
-	// todo more settings
+	rules           GameRules
 }
 
 type BuildSettlementCommand struct {
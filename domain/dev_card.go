@@ -1,4 +1,91 @@
 package domain
 
+import "errors"
+
+var (
+	// NoDevelopmentCardsLeftErr is used when the development cards deck is empty
+	NoDevelopmentCardsLeftErr = errors.New("no development cards left")
+	// DevelopmentCardIsNotOwnedErr is used when player plays a card he doesn't have
+	DevelopmentCardIsNotOwnedErr = errors.New("development card is not owned")
+	// DevelopmentCardIsNewErr is used when player plays a card on the turn it was bought
+	DevelopmentCardIsNewErr = errors.New("development card cannot be played on the turn it was bought")
+	// DevelopmentCardAlreadyPlayedErr is used when player plays the second card on the same turn
+	DevelopmentCardAlreadyPlayedErr = errors.New("development card was already played on this turn")
+	// WrongNumberOfResourcesToTakeErr is used when player takes not two resources by year of plenty
+	WrongNumberOfResourcesToTakeErr = errors.New("wrong number of resources to take")
+)
+
+// DevelopmentCard
+// is bought by players and played later for knights, progress effects or victory points
 type DevelopmentCard struct {
+	devCardType DevelopmentCardType
+}
+
+// DevelopmentCardType represents development card types
+type DevelopmentCardType string
+
+const (
+	// Knight moves the robber
+	Knight DevelopmentCardType = "knight"
+	// VictoryPoint is kept hidden and counts as a victory point
+	VictoryPoint DevelopmentCardType = "victory point"
+	// RoadBuilding gives two free roads
+	RoadBuilding DevelopmentCardType = "road building"
+	// YearOfPlenty gives two resources from the bank
+	YearOfPlenty DevelopmentCardType = "year of plenty"
+	// Monopoly takes every resource of one type from opponents
+	Monopoly DevelopmentCardType = "monopoly"
+)
+
+const (
+	// LargestArmyKnights is the least number of played knights to get largest army
+	LargestArmyKnights = 3
+	// LargestArmyVictoryPoints is given to the largest army owner
+	LargestArmyVictoryPoints = 2
+	// RoadBuildingRoads is the number of free roads given by road building
+	RoadBuildingRoads = 2
+	// YearOfPlentyResources is the number of resources taken from the bank by year of plenty
+	YearOfPlentyResources = 2
+)
+
+var (
+	DevelopmentCardKnight       = DevelopmentCard{devCardType: Knight}
+	DevelopmentCardVictoryPoint = DevelopmentCard{devCardType: VictoryPoint}
+	DevelopmentCardRoadBuilding = DevelopmentCard{devCardType: RoadBuilding}
+	DevelopmentCardYearOfPlenty = DevelopmentCard{devCardType: YearOfPlenty}
+	DevelopmentCardMonopoly     = DevelopmentCard{devCardType: Monopoly}
+)
+
+var _ Buyable = DevelopmentCard{}
+
+func (card DevelopmentCard) Type() DevelopmentCardType {
+	return card.devCardType
+}
+
+func (DevelopmentCard) Cost() []ResourceCard {
+	return []ResourceCard{ResourceCardOre, ResourceCardWheat, ResourceCardSheep}
+}
+
+// NewDevelopmentCardsDeck returns not shuffled deck of 25 cards
+func NewDevelopmentCardsDeck() []DevelopmentCard {
+	deckComposition := []struct {
+		card  DevelopmentCard
+		count int
+	}{
+		{card: DevelopmentCardKnight, count: 14},
+		{card: DevelopmentCardVictoryPoint, count: 5},
+		{card: DevelopmentCardRoadBuilding, count: 2},
+		{card: DevelopmentCardYearOfPlenty, count: 2},
+		{card: DevelopmentCardMonopoly, count: 2},
+	}
+
+	var deck []DevelopmentCard
+
+	for _, cards := range deckComposition {
+		for i := 0; i < cards.count; i++ {
+			deck = append(deck, cards.card)
+		}
+	}
+
+	return deck
 }
@@ -1,11 +1,43 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"math/rand"
+)
 
 type DiceRoller interface {
 	Roll() Roll
 }
 
+// RandomDiceRoller
+// rolls dice with the seed, the same seed always gives the same rolls
+type RandomDiceRoller struct {
+	seed   int64
+	crypto bool
+	random *rand.Rand
+}
+
+func NewRandomDiceRoller(seed int64) *RandomDiceRoller {
+	return &RandomDiceRoller{seed: seed, random: newRand(seed, false)}
+}
+
+// NewCryptoRandomDiceRoller creates the roller for competitive games, rolls can't be predicted
+func NewCryptoRandomDiceRoller() *RandomDiceRoller {
+	return &RandomDiceRoller{crypto: true, random: newRand(0, true)}
+}
+
+func (roller *RandomDiceRoller) Roll() Roll {
+	return NewRoll(roller.rollD6(), roller.rollD6())
+}
+
+func (roller *RandomDiceRoller) Seed() (int64, bool) {
+	return roller.seed, !roller.crypto
+}
+
+func (roller *RandomDiceRoller) rollD6() D6Roll {
+	return D6Roll(roller.random.Intn(6) + 1)
+}
+
 type NumberToken int64
 
 const NumberTokenEmpty = NumberToken(0)
@@ -21,6 +53,19 @@ func NewNumberToken(n int64) (NumberToken, error) {
 	return NumberToken(n), nil
 }
 
+// Pips returns the number of two dice combinations rolling the token, the empty token has none
+func (numberToken NumberToken) Pips() int64 {
+	if numberToken == NumberTokenEmpty {
+		return 0
+	}
+
+	if numberToken > 7 {
+		return int64(13 - numberToken)
+	}
+
+	return int64(numberToken - 1)
+}
+
 func MustGetNumberToken(n int64) NumberToken {
 	token, err := NewNumberToken(n)
 	if err != nil {
@@ -3,6 +3,8 @@ package domain
 import (
 	"reflect"
 	"time"
+
+	"github.com/rannoch/catan/grid"
 )
 
 type EventMessage interface {
@@ -52,8 +54,10 @@ func (e EventDescriptor) Occurred() time.Time {
 	return e.occurred
 }
 
+// GameCreated Seed seeds the resource picker and the development cards shuffler used until others are selected
 type GameCreated struct {
 	GameId GameId
+	Seed   int64
 }
 
 // todo before game started events
@@ -68,14 +72,36 @@ type PlayerLeftTheGameEvent struct {
 
 type BoardGeneratorSelectedEvent struct {
 	BoardGenerator BoardGenerator
+	// nil when the board can't be reproduced from the seed
+	Seed *int64
 }
 
 type PlayersShufflerSelectedEvent struct {
 	PlayersShuffler PlayersShuffler
+	// nil when the shuffler can't be reproduced from the seed
+	Seed *int64
 }
 
 type DiceRollerSelected struct {
 	DiceRoller DiceRoller
+	// nil when the roller can't be reproduced from the seed
+	Seed *int64
+}
+
+type DevelopmentCardsShufflerSelectedEvent struct {
+	DevelopmentCardsShuffler DevelopmentCardsShuffler
+	// nil when the shuffler can't be reproduced from the seed
+	Seed *int64
+}
+
+type ResourcePickerSelectedEvent struct {
+	ResourcePicker ResourcePicker
+	// nil when the picker can't be reproduced from the seed
+	Seed *int64
+}
+
+type GameRulesSelectedEvent struct {
+	GameRules GameRules
 }
 
 /// In-game events
@@ -89,6 +115,10 @@ type PlayersShuffledEvent struct {
 	PlayersInOrder []Color
 }
 
+type DevelopmentCardsShuffledEvent struct {
+	DevelopmentCards []DevelopmentCard
+}
+
 // Initial setup events
 type InitialSetupPhaseStartedEvent struct {
 }
@@ -118,24 +148,42 @@ type PlayerFinishedInitialSetupTurn struct {
 type PlayPhaseStartedEvent struct {
 }
 
+// GameFinishedEvent FinalScores include hidden victory points of development cards
+type GameFinishedEvent struct {
+	WinnerColor Color
+	FinalScores map[Color]int64
+}
+
 type PlayerRolledDiceEvent struct {
 	Roll Roll
 }
 
+type PlayerMovedRobberEvent struct {
+	PlayerColor Color
+	HexCoord    grid.HexCoord
+}
+
 type PlayerPickedResourcesEvent struct {
 	PlayerColor     Color
 	PickedResources []ResourceCard
 }
 
+// PlayerReceivedStartingResourcesEvent
+// the player receives a resource from every hex around his second settlement of the initial setup
+type PlayerReceivedStartingResourcesEvent struct {
+	PlayerColor Color
+	Resources   []ResourceCard
+}
+
 type PlayerWasRobbedByRobberEvent struct {
-	robbedPlayerColor Color
-	dumpedResources   []ResourceCard
+	RobbedPlayerColor Color
+	DumpedResources   []ResourceCard
 }
 
 type PlayerWasRobbedByPlayerEvent struct {
-	robbingPlayerColor Color
-	robbedPlayerColor  Color
-	dumpedResources    []ResourceCard
+	RobbingPlayerColor Color
+	RobbedPlayerColor  Color
+	DumpedResources    []ResourceCard
 }
 
 type PlayerStartedHisTurnEvent struct {
@@ -146,12 +194,115 @@ type PlayerFinishedHisTurnEvent struct {
 	PlayerColor Color
 }
 
+// SpecialBuildingPhaseStartedEvent
+// other players build one by one in the turn order after the current player ended his turn
+type SpecialBuildingPhaseStartedEvent struct {
+	PlayerColors []Color
+}
+
+type PlayerStartedSpecialBuildingEvent struct {
+	PlayerColor Color
+}
+
+type PlayerFinishedSpecialBuildingEvent struct {
+	PlayerColor Color
+}
+
+type SpecialBuildingPhaseFinishedEvent struct{}
+
 type PlayerPlacedSettlementEvent struct {
 	PlayerColor Color
 	Settlement  Settlement
 }
 
+type TradeOfferedEvent struct {
+	TradeOfferId   int64
+	PlayerColor    Color
+	GivenResources []ResourceCard
+	TakenResources []ResourceCard
+	TargetColors   []Color
+}
+
+type TradeOfferAcceptedEvent struct {
+	TradeOfferId int64
+	PlayerColor  Color
+}
+
+type TradeOfferRejectedEvent struct {
+	TradeOfferId int64
+	PlayerColor  Color
+}
+
+// TradeOfferCounteredEvent resources are given and taken by the countering player,
+// the counter offer is opened for the offering player and is accepted by the countering player
+type TradeOfferCounteredEvent struct {
+	TradeOfferId        int64
+	CounterTradeOfferId int64
+	PlayerColor         Color
+	GivenResources      []ResourceCard
+	TakenResources      []ResourceCard
+}
+
+// TradeConfirmedEvent resources are given and taken by the offering player
+type TradeConfirmedEvent struct {
+	TradeOfferId        int64
+	PlayerColor         Color
+	AcceptedPlayerColor Color
+	GivenResources      []ResourceCard
+	TakenResources      []ResourceCard
+}
+
+// TradeOfferInvalidatedEvent is used when the offering player's hand doesn't cover the offer anymore
+type TradeOfferInvalidatedEvent struct {
+	TradeOfferId int64
+}
+
+type PlayerTradedWithBankEvent struct {
+	PlayerColor    Color
+	GivenResources []ResourceCard
+	TakenResources []ResourceCard
+}
+
+type PlayerPlacedCityEvent struct {
+	PlayerColor Color
+	City        City
+}
+
 type PlayerPlacedRoadEvent struct {
 	PlayerColor Color
 	Road        Road
 }
+
+type PlayerBoughtDevelopmentCardEvent struct {
+	PlayerColor     Color
+	DevelopmentCard DevelopmentCard
+}
+
+type PlayerPlayedDevelopmentCardEvent struct {
+	PlayerColor     Color
+	DevelopmentCard DevelopmentCard
+}
+
+// PlayerCollectedMonopolyResourcesEvent CollectedResources holds the number of cards taken from every opponent
+type PlayerCollectedMonopolyResourcesEvent struct {
+	PlayerColor        Color
+	Resource           Resource
+	CollectedResources map[Color]int64
+}
+
+// LongestRoadOwnerChangedEvent PreviousOwnerColor is None when nobody had longest road before
+type LongestRoadOwnerChangedEvent struct {
+	PlayerColor        Color
+	PreviousOwnerColor Color
+}
+
+// LongestRoadLostEvent is used when the owner's road is broken and nobody else has the longest road alone
+type LongestRoadLostEvent struct {
+	PlayerColor Color
+}
+
+// LargestArmyOwnerChangedEvent PreviousOwnerColor is None when nobody had largest army before
+type LargestArmyOwnerChangedEvent struct {
+	PlayerColor        Color
+	PreviousOwnerColor Color
+}
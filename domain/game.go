@@ -4,6 +4,8 @@ import (
 	"errors"
 	"reflect"
 	"time"
+
+	"github.com/rannoch/catan/grid"
 )
 
 type (
@@ -20,6 +22,8 @@ var (
 	PlayerNotExistsErr = errors.New("player does not exist")
 	// WrongTurnErr is occurred when player tries to do something during not his turn
 	WrongTurnErr = errors.New("wrong turn")
+	// WrongPieceColorErr is occurred when player tries to place a piece of another color
+	WrongPieceColorErr = errors.New("piece has another color")
 )
 
 // Game aggregate
@@ -33,6 +37,7 @@ type Game struct {
 	stateStarted      GameState
 	stateInitialSetup GameState
 	statePlay         GameState
+	stateFinished     GameState
 
 	currentState GameState
 
@@ -49,9 +54,24 @@ type Game struct {
 	boardGenerator  BoardGenerator
 	playersShuffler PlayersShuffler
 	diceRoller      DiceRoller
+	resourcePicker  ResourcePicker
+
+	developmentCardsShuffler DevelopmentCardsShuffler
+	developmentCardsDeck     []DevelopmentCard
+
+	rules GameRules
+
+	// the current player wins when he has at least rules.VictoryPointsToWin on his turn
+	winner      Color
+	finalScores map[Color]int64
+
+	// cards of every resource left in the bank
+	bankResources map[ResourceCard]int64
+
+	// trade offers of the current turn
+	tradeOffers      []TradeOffer
+	lastTradeOfferId int64
 
-	availableResources map[ResourceCard][]ResourceCard // todo properly
-	// todo trades
 	// todo turn
 }
 
@@ -62,6 +82,7 @@ func NewGame(id GameId, occurred time.Time) *Game {
 
 	game.Apply(NewEventDescriptor(id, GameCreated{
 		GameId: id,
+		Seed:   occurred.UnixNano(),
 	}, nil, game.Version(), occurred), true)
 
 	return game
@@ -83,6 +104,18 @@ func (game *Game) SetDiceRoller(diceRoller DiceRoller, occurred time.Time) error
 	return game.currentState.SetDiceRoller(diceRoller, occurred)
 }
 
+func (game *Game) SetDevelopmentCardsShuffler(developmentCardsShuffler DevelopmentCardsShuffler, occurred time.Time) error {
+	return game.currentState.SetDevelopmentCardsShuffler(developmentCardsShuffler, occurred)
+}
+
+func (game *Game) SetResourcePicker(resourcePicker ResourcePicker, occurred time.Time) error {
+	return game.currentState.SetResourcePicker(resourcePicker, occurred)
+}
+
+func (game *Game) SetGameRules(rules GameRules, occurred time.Time) error {
+	return game.currentState.SetGameRules(rules, occurred)
+}
+
 func (game *Game) GenerateBoard(occurred time.Time) error {
 	return game.currentState.GenerateBoard(occurred)
 }
@@ -91,6 +124,10 @@ func (game *Game) ShufflePlayers(occurred time.Time) error {
 	return game.currentState.ShufflePlayers(occurred)
 }
 
+func (game *Game) ShuffleDevelopmentCards(occurred time.Time) error {
+	return game.currentState.ShuffleDevelopmentCards(occurred)
+}
+
 func (game *Game) StartGame(
 	occurred time.Time,
 ) error {
@@ -124,18 +161,73 @@ func (game *Game) BuyCity(playerColor Color, occurred time.Time) error {
 	return game.currentState.BuyCity(playerColor, occurred)
 }
 
-func (game *Game) BuyDevelopmentCard(playerColor Color) error {
-	return game.currentState.BuyDevelopmentCard(playerColor)
+func (game *Game) BuyDevelopmentCard(playerColor Color, occurred time.Time) error {
+	return game.currentState.BuyDevelopmentCard(playerColor, occurred)
+}
+
+func (game *Game) PlayDevelopmentCard(playerColor Color, card DevelopmentCard, occurred time.Time) error {
+	return game.currentState.PlayDevelopmentCard(playerColor, card, occurred)
+}
+
+// MaritimeTrade gives the bank cards of one resource for one card of another resource at the best player's rate
+func (game *Game) MaritimeTrade(playerColor Color, givenResource ResourceCard, takenResource ResourceCard, occurred time.Time) error {
+	return game.currentState.MaritimeTrade(playerColor, givenResource, takenResource, occurred)
+}
+
+// ProposeTrade offers opponents to trade, empty target colors offer the trade to everybody
+func (game *Game) ProposeTrade(playerColor Color, givenResources []ResourceCard, takenResources []ResourceCard, targetColors []Color, occurred time.Time) error {
+	return game.currentState.ProposeTrade(playerColor, givenResources, takenResources, targetColors, occurred)
+}
+
+func (game *Game) AcceptTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error {
+	return game.currentState.AcceptTradeOffer(playerColor, tradeOfferId, occurred)
+}
+
+func (game *Game) RejectTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error {
+	return game.currentState.RejectTradeOffer(playerColor, tradeOfferId, occurred)
+}
+
+// CounterTradeOffer proposes other resources to the offering player, resources are given and taken by the countering player
+func (game *Game) CounterTradeOffer(playerColor Color, tradeOfferId int64, givenResources []ResourceCard, takenResources []ResourceCard, occurred time.Time) error {
+	return game.currentState.CounterTradeOffer(playerColor, tradeOfferId, givenResources, takenResources, occurred)
+}
+
+func (game *Game) ConfirmTrade(playerColor Color, tradeOfferId int64, acceptedPlayerColor Color, occurred time.Time) error {
+	return game.currentState.ConfirmTrade(playerColor, tradeOfferId, acceptedPlayerColor, occurred)
+}
+
+func (game *Game) PlayYearOfPlenty(playerColor Color, resources []ResourceCard, occurred time.Time) error {
+	return game.currentState.PlayYearOfPlenty(playerColor, resources, occurred)
+}
+
+func (game *Game) PlayMonopoly(playerColor Color, resource Resource, occurred time.Time) error {
+	return game.currentState.PlayMonopoly(playerColor, resource, occurred)
 }
 
 func (game *Game) PlaceSettlement(playerColor Color, settlement Settlement, occurred time.Time) error {
 	return game.currentState.PlaceSettlement(playerColor, settlement, occurred)
 }
 
+func (game *Game) PlaceCity(playerColor Color, city City, occurred time.Time) error {
+	return game.currentState.PlaceCity(playerColor, city, occurred)
+}
+
 func (game *Game) PlaceRoad(playerColor Color, road Road, occurred time.Time) error {
 	return game.currentState.PlaceRoad(playerColor, road, occurred)
 }
 
+func (game *Game) PlaceRobber(playerColor Color, hexCoord grid.HexCoord, occurred time.Time) error {
+	return game.currentState.PlaceRobber(playerColor, hexCoord, occurred)
+}
+
+func (game *Game) RobPlayer(playerColor Color, targetColor Color, occurred time.Time) error {
+	return game.currentState.RobPlayer(playerColor, targetColor, occurred)
+}
+
+func (game *Game) DiscardResources(playerColor Color, resources []ResourceCard, occurred time.Time) error {
+	return game.currentState.DiscardResources(playerColor, resources, occurred)
+}
+
 func (game *Game) RollDice(playerColor Color, occurred time.Time) error {
 	return game.currentState.RollDice(playerColor, occurred)
 }
@@ -158,33 +250,24 @@ func (game *Game) Apply(eventMessage EventMessage, isNew bool) {
 		gameStatePlayerIsToPlaceSettlement := NewGameStatePlayerIsToPlaceSettlement(game)
 		gameStatePlayerIsToPlaceRoad := NewGameStatePlayerIsToPlaceRoad(game)
 		gameStatePlayerIsRollingDice := NewGameStatePlayerIsRollingDice(game)
+		gameStatePlayerIsPlacingRobber := NewGameStatePlayerIsPlacingRobber(game)
+		gameStatePlayersAreDiscardingResources := NewGameStatePlayersAreDiscardingResources(game)
+		gameStatePlayerSelectingWhoToRob := NewGameStatePlayerSelectingWhoToRob(game)
 
 		game.id = event.GameId
+		game.rules = DefaultGameRules()
+		game.winner = None
+		game.bankResources = newBankResources(game.rules.BankResourcesPerType)
+		game.resourcePicker = NewRandomResourcePicker(event.Seed)
+		game.developmentCardsShuffler = NewRandomDevelopmentCardsShuffler(event.Seed)
 		game.stateNew = NewGameStateNew(game)
 		game.stateStarted = NewGameStateStarted(game)
 		game.stateInitialSetup = NewGameStateInitialSetup(game, gameStatePlayerIsToPlaceSettlement, gameStatePlayerIsToPlaceRoad)
-		game.statePlay = NewGameStatePlay(game, gameStatePlayerIsRollingDice, gameStatePlayerIsToPlaceSettlement, gameStatePlayerIsToPlaceRoad)
+		game.statePlay = NewGameStatePlay(game, gameStatePlayerIsRollingDice, gameStatePlayersAreDiscardingResources, gameStatePlayerIsPlacingRobber, gameStatePlayerSelectingWhoToRob, gameStatePlayerIsToPlaceSettlement, gameStatePlayerIsToPlaceRoad)
 
-		game.setState(game.stateNew)
-	case PlayerPlacedRoadEvent:
-		game.trackChangeAndIncrementVersion(eventMessage)
-
-		player, err := game.Player(event.PlayerColor)
-		if err != nil {
-			panic(err)
-		}
-
-		player.availableRoads--
-
-		err = game.updatePlayer(player)
-		if err != nil {
-			panic(err)
-		}
+		game.stateFinished = NewGameStateFinished(game)
 
-		err = game.placeRoad(event.Road)
-		if err != nil {
-			panic(err)
-		}
+		game.setState(game.stateNew)
 	case PlayerPlacedInitialRoadEvent: // todo remove duplicate
 		game.trackChangeAndIncrementVersion(eventMessage)
 
@@ -204,26 +287,6 @@ func (game *Game) Apply(eventMessage EventMessage, isNew bool) {
 		if err != nil {
 			panic(err)
 		}
-	case PlayerPlacedSettlementEvent:
-		game.trackChangeAndIncrementVersion(eventMessage)
-
-		player, err := game.Player(event.PlayerColor)
-		if err != nil {
-			panic(err)
-		}
-
-		player.victoryPoints += event.Settlement.VictoryPoints()
-		player.availableSettlements--
-
-		err = game.updatePlayer(player)
-		if err != nil {
-			panic(err)
-		}
-
-		err = game.placeSettlement(event.Settlement)
-		if err != nil {
-			panic(err)
-		}
 	case PlayerPlacedInitialSettlementEvent: // todo remove duplicate
 		game.trackChangeAndIncrementVersion(eventMessage)
 
@@ -246,6 +309,13 @@ func (game *Game) Apply(eventMessage EventMessage, isNew bool) {
 		}
 	case PlayPhaseStartedEvent:
 		game.setState(game.statePlay)
+	case GameFinishedEvent:
+		game.winner = event.WinnerColor
+		game.finalScores = event.FinalScores
+		game.setCurrentTurn(None)
+		game.setState(game.stateFinished)
+	default:
+		game.currentState.Apply(eventMessage, isNew)
 	}
 }
 
@@ -307,6 +377,20 @@ func (game Game) TotalTurns() int64 {
 	return game.totalTurns
 }
 
+// Winner returns None until the game is finished
+func (game Game) Winner() Color {
+	return game.winner
+}
+
+// FinalScores returns victory points of every player including hidden ones when the game is finished
+func (game Game) FinalScores() map[Color]int64 {
+	return game.finalScores
+}
+
+func (game Game) VictoryPointsToWin() int64 {
+	return game.rules.VictoryPointsToWin
+}
+
 func (game Game) CurrentTurn() Color {
 	return game.currentTurn
 }
@@ -317,6 +401,21 @@ func (game Game) NextTurnColor() Color {
 	return color
 }
 
+// TradeOffers returns open trade offers of the current turn
+func (game Game) TradeOffers() []TradeOffer {
+	return game.tradeOffers
+}
+
+func (game Game) TradeOffer(tradeOfferId int64) (TradeOffer, bool) {
+	for _, tradeOffer := range game.tradeOffers {
+		if tradeOffer.Id() == tradeOfferId {
+			return tradeOffer, true
+		}
+	}
+
+	return TradeOffer{}, false
+}
+
 func (game *Game) TurnOrder() []Color {
 	return game.currentState.TurnOrder()
 }
@@ -333,6 +432,31 @@ func (game *Game) PlayersShuffler() PlayersShuffler {
 	return game.playersShuffler
 }
 
+func (game Game) HandLimit() int64 {
+	return game.rules.HandLimit
+}
+
+func (game Game) Rules() GameRules {
+	return game.rules
+}
+
+func (game *Game) DevelopmentCardsShuffler() DevelopmentCardsShuffler {
+	return game.developmentCardsShuffler
+}
+
+// AvailableDevelopmentCards returns the number of cards left in the deck
+func (game Game) AvailableDevelopmentCards() int64 {
+	return int64(len(game.developmentCardsDeck))
+}
+
+func (game *Game) ResourcePicker() ResourcePicker {
+	return game.resourcePicker
+}
+
+func (game *Game) DiceRoller() DiceRoller {
+	return game.diceRoller
+}
+
 func (game *Game) BoardGenerator() BoardGenerator {
 	return game.boardGenerator
 }
@@ -356,6 +480,56 @@ func (game *Game) placeSettlement(settlement Settlement) error {
 	return game.Board().UpdateIntersection(settlement.IntersectionCoord(), intersection)
 }
 
+func (game *Game) addTradeOffer(tradeOffer TradeOffer) {
+	game.tradeOffers = append(game.tradeOffers, tradeOffer)
+	game.lastTradeOfferId = tradeOffer.Id()
+}
+
+func (game *Game) updateTradeOffer(tradeOffer TradeOffer) {
+	for i := range game.tradeOffers {
+		if game.tradeOffers[i].Id() == tradeOffer.Id() {
+			game.tradeOffers[i] = tradeOffer
+			return
+		}
+	}
+}
+
+func (game *Game) removeTradeOffer(tradeOfferId int64) {
+	var tradeOffers []TradeOffer
+
+	for _, tradeOffer := range game.tradeOffers {
+		if tradeOffer.Id() != tradeOfferId {
+			tradeOffers = append(tradeOffers, tradeOffer)
+		}
+	}
+
+	game.tradeOffers = tradeOffers
+}
+
+// updateLongestRoads recalculates longest roads of all players after the board is changed
+func (game *Game) updateLongestRoads() {
+	for _, player := range game.Players() {
+		player.longestRoad = LongestRoad(game.Board(), player.Color())
+
+		err := game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// placeCity replaces the settlement with the city
+func (game *Game) placeCity(city City) error {
+	intersection, exists := game.Board().Intersection(city.IntersectionCoord())
+	if !exists {
+		return BadIntersectionCoordErr
+	}
+
+	intersection.SetBuilding(city)
+
+	return game.Board().UpdateIntersection(city.IntersectionCoord(), intersection)
+}
+
 func (game *Game) placeRoad(road Road) error {
 	path, exists := game.Board().Path(road.PathCoord())
 	if !exists {
@@ -438,6 +612,23 @@ func (game *Game) setDiceRoller(diceRoller DiceRoller) {
 	game.diceRoller = diceRoller
 }
 
+func (game *Game) setDevelopmentCardsShuffler(developmentCardsShuffler DevelopmentCardsShuffler) {
+	game.developmentCardsShuffler = developmentCardsShuffler
+}
+
+func (game *Game) setDevelopmentCardsDeck(developmentCards []DevelopmentCard) {
+	game.developmentCardsDeck = developmentCards
+}
+
+func (game *Game) setResourcePicker(resourcePicker ResourcePicker) {
+	game.resourcePicker = resourcePicker
+}
+
+func (game *Game) setRules(rules GameRules) {
+	game.rules = rules
+	game.bankResources = newBankResources(rules.BankResourcesPerType)
+}
+
 func (game *Game) incrementTotalTurns() {
 	game.totalTurns++
 }
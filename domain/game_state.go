@@ -15,9 +15,13 @@ type GameState interface {
 	SetBoardGenerator(boardGenerator BoardGenerator, occurred time.Time) error
 	SetPlayersShuffler(playersShuffler PlayersShuffler, occurred time.Time) error
 	SetDiceRoller(diceRoller DiceRoller, occurred time.Time) error
+	SetResourcePicker(resourcePicker ResourcePicker, occurred time.Time) error
+	SetDevelopmentCardsShuffler(developmentCardsShuffler DevelopmentCardsShuffler, occurred time.Time) error
+	SetGameRules(rules GameRules, occurred time.Time) error
 
 	GenerateBoard(occurred time.Time) error
 	ShufflePlayers(occurred time.Time) error
+	ShuffleDevelopmentCards(occurred time.Time) error
 
 	AddPlayer(player Player, occurred time.Time) error
 	RemovePlayer(player Player, occurred time.Time) error
@@ -34,19 +38,37 @@ type GameState interface {
 
 	PlaceSettlement(playerColor Color, settlement Settlement, occurred time.Time) error
 
+	PlaceCity(playerColor Color, city City, occurred time.Time) error
+
 	PlaceRoad(playerColor Color, road Road, occurred time.Time) error
 
-	PlaceRobber(playerColor Color, hexCoord grid.HexCoord) error
+	PlaceRobber(playerColor Color, hexCoord grid.HexCoord, occurred time.Time) error
+
+	RobPlayer(playerColor Color, targetColor Color, occurred time.Time) error
+
+	DiscardResources(playerColor Color, resources []ResourceCard, occurred time.Time) error
+
+	BuyDevelopmentCard(playerColor Color, occurred time.Time) error
 
-	RobPlayer(playerColor Color, targetColor Color) error
+	PlayDevelopmentCard(playerColor Color, card DevelopmentCard, occurred time.Time) error
 
-	BuyDevelopmentCard(playerColor Color) error
+	MaritimeTrade(playerColor Color, givenResource ResourceCard, takenResource ResourceCard, occurred time.Time) error
 
-	PlayDevelopmentCard(playerColor Color, card DevelopmentCard) error
+	ProposeTrade(playerColor Color, givenResources []ResourceCard, takenResources []ResourceCard, targetColors []Color, occurred time.Time) error
+	AcceptTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error
+	RejectTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error
+	CounterTradeOffer(playerColor Color, tradeOfferId int64, givenResources []ResourceCard, takenResources []ResourceCard, occurred time.Time) error
+	ConfirmTrade(playerColor Color, tradeOfferId int64, acceptedPlayerColor Color, occurred time.Time) error
+
+	PlayYearOfPlenty(playerColor Color, resources []ResourceCard, occurred time.Time) error
+
+	PlayMonopoly(playerColor Color, resource Resource, occurred time.Time) error
 
 	TurnOrder() []Color
 	EndTurn(playerColor Color, occurred time.Time) error
 	CurrentTurn() Color
+
+	Apply(eventMessage EventMessage, isNew bool)
 }
 
 type GameStateDefault struct{}
@@ -75,6 +97,18 @@ func (d GameStateDefault) SetDiceRoller(DiceRoller, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
+func (d GameStateDefault) SetDevelopmentCardsShuffler(DevelopmentCardsShuffler, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) SetResourcePicker(ResourcePicker, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) SetGameRules(GameRules, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
 func (d GameStateDefault) GenerateBoard(time.Time) error {
 	return CommandIsForbiddenErr
 }
@@ -83,6 +117,10 @@ func (d GameStateDefault) ShufflePlayers(time.Time) error {
 	return CommandIsForbiddenErr
 }
 
+func (d GameStateDefault) ShuffleDevelopmentCards(time.Time) error {
+	return CommandIsForbiddenErr
+}
+
 func (GameStateDefault) StartGame(time.Time) error {
 	return CommandIsForbiddenErr
 }
@@ -107,23 +145,63 @@ func (GameStateDefault) PlaceSettlement(Color, Settlement, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
+func (GameStateDefault) PlaceCity(Color, City, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
 func (GameStateDefault) PlaceRoad(Color, Road, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
-func (d GameStateDefault) PlaceRobber(Color, grid.HexCoord) error {
+func (d GameStateDefault) PlaceRobber(Color, grid.HexCoord, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) RobPlayer(Color, Color, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) DiscardResources(Color, []ResourceCard, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (GameStateDefault) BuyDevelopmentCard(Color, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) PlayDevelopmentCard(Color, DevelopmentCard, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) MaritimeTrade(Color, ResourceCard, ResourceCard, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) ProposeTrade(Color, []ResourceCard, []ResourceCard, []Color, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) AcceptTradeOffer(Color, int64, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) RejectTradeOffer(Color, int64, time.Time) error {
+	return CommandIsForbiddenErr
+}
+
+func (d GameStateDefault) CounterTradeOffer(Color, int64, []ResourceCard, []ResourceCard, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
-func (d GameStateDefault) RobPlayer(Color, Color) error {
+func (d GameStateDefault) ConfirmTrade(Color, int64, Color, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
-func (GameStateDefault) BuyDevelopmentCard(Color) error {
+func (d GameStateDefault) PlayYearOfPlenty(Color, []ResourceCard, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
-func (d GameStateDefault) PlayDevelopmentCard(playerColor Color, card DevelopmentCard) error {
+func (d GameStateDefault) PlayMonopoly(Color, Resource, time.Time) error {
 	return CommandIsForbiddenErr
 }
 
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rannoch/catan/grid"
+)
+
+// GameStateFinished is the terminal state, every command is rejected
+type GameStateFinished struct {
+	GameStateDefault
+	game *Game
+}
+
+func NewGameStateFinished(game *Game) *GameStateFinished {
+	return &GameStateFinished{game: game}
+}
+
+var _ GameState = (*GameStateFinished)(nil)
+
+func (gameStateFinished *GameStateFinished) TurnOrder() []Color {
+	return gameStateFinished.game.turnOrder
+}
+
+func (GameStateFinished) CurrentTurn() Color {
+	return None
+}
+
+func (GameStateFinished) SetBoardGenerator(BoardGenerator, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) SetPlayersShuffler(PlayersShuffler, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) SetDiceRoller(DiceRoller, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) SetResourcePicker(ResourcePicker, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) SetDevelopmentCardsShuffler(DevelopmentCardsShuffler, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) SetGameRules(GameRules, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) GenerateBoard(time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) ShufflePlayers(time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) ShuffleDevelopmentCards(time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) AddPlayer(Player, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) RemovePlayer(Player, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) StartGame(time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) RollDice(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) BuyRoad(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) BuySettlement(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) BuyCity(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlaceSettlement(Color, Settlement, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlaceCity(Color, City, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlaceRoad(Color, Road, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlaceRobber(Color, grid.HexCoord, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) RobPlayer(Color, Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) DiscardResources(Color, []ResourceCard, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) BuyDevelopmentCard(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlayDevelopmentCard(Color, DevelopmentCard, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) MaritimeTrade(Color, ResourceCard, ResourceCard, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) ProposeTrade(Color, []ResourceCard, []ResourceCard, []Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) AcceptTradeOffer(Color, int64, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) RejectTradeOffer(Color, int64, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) CounterTradeOffer(Color, int64, []ResourceCard, []ResourceCard, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) ConfirmTrade(Color, int64, Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlayYearOfPlenty(Color, []ResourceCard, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) PlayMonopoly(Color, Resource, time.Time) error {
+	return GameAlreadyFinishedErr
+}
+
+func (GameStateFinished) EndTurn(Color, time.Time) error {
+	return GameAlreadyFinishedErr
+}
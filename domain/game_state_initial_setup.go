@@ -7,33 +7,28 @@ import (
 )
 
 type GameStateInitialSetup struct {
-	turnOrder []Color
+	game *Game
 
-	currentTurn int
+	settlements []Settlement
 
-	GameStateDefault
+	playerIsToPlaceRoadAdjacentToBuilding grid.IntersectionCoord
 
-	game *Game
+	currentSubState                GameState
+	statePlayerIsPlacingSettlement GameState
+	statePlayerIsPlacingRoad       GameState
+
+	GameStateDefault
 }
 
 func NewGameStateInitialSetup(
-	gameStateDefault GameStateDefault,
 	game *Game,
+	statePlayerIsPlacingSettlement GameState,
+	statePlayerIsPlacingRoad GameState,
 ) *GameStateInitialSetup {
-	var turnOrderReversed = make([]Color, len(game.turnOrder))
-	copy(turnOrderReversed, game.turnOrder)
-
-	for i := len(turnOrderReversed)/2 - 1; i >= 0; i-- {
-		opp := len(turnOrderReversed) - 1 - i
-		turnOrderReversed[i], turnOrderReversed[opp] = turnOrderReversed[opp], turnOrderReversed[i]
-	}
-
-	initialSetupTurnOrder := append(game.turnOrder, turnOrderReversed...)
-
 	return &GameStateInitialSetup{
-		turnOrder:        initialSetupTurnOrder,
-		GameStateDefault: gameStateDefault,
-		game:             game,
+		game:                           game,
+		statePlayerIsPlacingSettlement: statePlayerIsPlacingSettlement,
+		statePlayerIsPlacingRoad:       statePlayerIsPlacingRoad,
 	}
 }
 
@@ -49,11 +44,62 @@ func (gameStatusInitialSetup *GameStateInitialSetup) EnterState(occurred time.Ti
 		return
 	}
 
-	// change state to next turn
 	game := gameStatusInitialSetup.game
 
-	gameStateInitialSetupPlayerTurn := NewGameStateInitialSetupPlayerTurn(game, gameStatusInitialSetup.CurrentTurn(), gameStatusInitialSetup)
-	game.ChangeState(gameStateInitialSetupPlayerTurn, occurred)
+	playerStartedHisTurnEventMessage := NewEventDescriptor(
+		game.Id(),
+		PlayerStartedHisTurnEvent{
+			PlayerColor: gameStatusInitialSetup.TurnOrder()[0],
+		},
+		nil,
+		game.Version(),
+		occurred,
+	)
+
+	game.Apply(playerStartedHisTurnEventMessage, true)
+}
+
+func (gameStatusInitialSetup *GameStateInitialSetup) PlaceSettlement(playerColor Color, settlement Settlement, occurred time.Time) error {
+	if err := gameStatusInitialSetup.currentSubState.PlaceSettlement(playerColor, settlement, occurred); err != nil {
+		return err
+	}
+
+	gameStatusInitialSetup.giveStartingResources(playerColor, settlement, occurred)
+
+	return nil
+}
+
+// giveStartingResources gives resources around the second settlement
+func (gameStatusInitialSetup *GameStateInitialSetup) giveStartingResources(playerColor Color, settlement Settlement, occurred time.Time) {
+	game := gameStatusInitialSetup.game
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		panic(err)
+	}
+
+	if !player.HasPlacedInitialBuildings(game.Rules()) {
+		return
+	}
+
+	resources := gameStatusInitialSetup.getInitialResources(settlement.IntersectionCoord())
+	if len(resources) == 0 {
+		return
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerReceivedStartingResourcesEvent{
+				PlayerColor: playerColor,
+				Resources:   resources,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
 }
 
 func (gameStatusInitialSetup *GameStateInitialSetup) PlaceRoad(playerColor Color, road Road, occurred time.Time) error {
@@ -134,11 +180,21 @@ func (gameStatusInitialSetup *GameStateInitialSetup) endTurn(playerColor Color,
 }
 
 func (gameStatusInitialSetup *GameStateInitialSetup) CurrentTurn() Color {
-	return gameStatusInitialSetup.turnOrder[gameStatusInitialSetup.currentTurn]
+	return gameStatusInitialSetup.game.CurrentTurn()
 }
 
+// TurnOrder returns snake order: every player places first buildings in the play order, second ones in the reversed order
 func (gameStatusInitialSetup *GameStateInitialSetup) TurnOrder() []Color {
-	return gameStatusInitialSetup.turnOrder
+	game := gameStatusInitialSetup.game
+
+	turnOrder := make([]Color, 0, 2*len(game.turnOrder))
+	turnOrder = append(turnOrder, game.turnOrder...)
+
+	for i := len(game.turnOrder) - 1; i >= 0; i-- {
+		turnOrder = append(turnOrder, game.turnOrder[i])
+	}
+
+	return turnOrder
 }
 
 func (gameStatusInitialSetup *GameStateInitialSetup) Apply(eventMessage EventMessage, isNew bool) {
@@ -157,6 +213,8 @@ func (gameStatusInitialSetup *GameStateInitialSetup) Apply(eventMessage EventMes
 		gameStatusInitialSetup.settlements = append(gameStatusInitialSetup.settlements, event.Settlement)
 		gameStatusInitialSetup.currentSubState = gameStatusInitialSetup.statePlayerIsPlacingRoad
 		gameStatusInitialSetup.playerIsToPlaceRoadAdjacentToBuilding = event.Settlement.IntersectionCoord()
+	case PlayerPlacedRoadEvent:
+		gameStatusInitialSetup.currentSubState.Apply(eventMessage, isNew)
 	case PlayerPickedResourcesEvent:
 		player, err := game.Player(event.PlayerColor)
 		if err != nil {
@@ -164,6 +222,20 @@ func (gameStatusInitialSetup *GameStateInitialSetup) Apply(eventMessage EventMes
 		}
 
 		player.GainResources(event.PickedResources)
+		game.takeFromBank(event.PickedResources)
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	case PlayerReceivedStartingResourcesEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.GainResources(event.Resources)
+		game.takeFromBank(event.Resources)
 
 		err = game.updatePlayer(player)
 		if err != nil {
@@ -176,12 +248,21 @@ func (gameStatusInitialSetup *GameStateInitialSetup) checkAndMoveToPlayStateIfNe
 	game := gameStatusInitialSetup.game
 
 	for _, player := range game.Players() {
-		if !player.HasPlacedInitialBuildingsAndRoads() {
+		if !player.HasPlacedInitialBuildingsAndRoads(game.Rules()) {
 			return false
 		}
 	}
 
-	game.ChangeState(NewGameStatePlay(game), occurred)
+	playPhaseStartedEventMessage := NewEventDescriptor(
+		game.Id(),
+		PlayPhaseStartedEvent{},
+		nil,
+		game.Version(),
+		occurred,
+	)
+
+	game.Apply(playPhaseStartedEventMessage, true)
+	game.currentState.EnterState(occurred)
 
 	return true
 }
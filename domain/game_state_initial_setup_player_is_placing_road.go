@@ -35,6 +35,10 @@ func (g *GameStateInitialSetupPlayerIsPlacingRoad) PlaceRoad(playerColor Color,
 		return WrongTurnErr
 	}
 
+	if road.color != playerColor {
+		return WrongPieceColorErr
+	}
+
 	_, err := game.Player(playerColor)
 	if err != nil {
 		return err
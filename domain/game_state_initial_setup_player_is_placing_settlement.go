@@ -23,7 +23,11 @@ func (g *GameStateInitialSetupPlayerIsPlacingSettlement) PlaceSettlement(playerC
 		return WrongTurnErr
 	}
 
-	if err := g.canBuildSettlement(settlement); err != nil {
+	if settlement.Color() != playerColor {
+		return WrongPieceColorErr
+	}
+
+	if err := canBuildSettlement(game.Board(), settlement); err != nil {
 		return err
 	}
 
@@ -42,31 +46,3 @@ func (g *GameStateInitialSetupPlayerIsPlacingSettlement) PlaceSettlement(playerC
 
 	return nil
 }
-
-func (g *GameStateInitialSetupPlayerIsPlacingSettlement) canBuildSettlement(settlement Settlement) error {
-	game := g.game
-
-	intersection, exists := game.Board().Intersection(settlement.IntersectionCoord())
-	if !exists {
-		return BadIntersectionCoordErr
-	}
-
-	if !intersection.IsEmpty() {
-		return IntersectionAlreadyHasObjectErr
-	}
-
-	// distance check
-	adjacentIntersectionsCoords := game.Board().IntersectionAdjacentIntersections(settlement.intersectionCoord)
-	for _, adjacentIntersectionCoord := range adjacentIntersectionsCoords {
-		adjacentIntersection, exists := game.Board().Intersection(adjacentIntersectionCoord)
-		if !exists {
-			continue
-		}
-
-		if !adjacentIntersection.IsEmpty() {
-			return CommandIsForbiddenErr
-		}
-	}
-
-	return nil
-}
@@ -8,6 +8,7 @@ import (
 var (
 	BoardGeneratorIsNotSelectedErr  = errors.New("board generator is not selected")
 	PlayersShufflerIsNotSelectedErr = errors.New("players shuffler is not selected")
+	DiceRollerIsNotSelectedErr      = errors.New("dice roller is not selected")
 	NoPlayersErr                    = errors.New("cannot start the game without players")
 )
 
@@ -25,7 +26,7 @@ var _ GameState = (*GameStateNew)(nil)
 func (gameStateNew *GameStateNew) SetBoardGenerator(boardGenerator BoardGenerator, occurred time.Time) error {
 	eventMessage := EventDescriptor{
 		id:       gameStateNew.game.Id(),
-		event:    BoardGeneratorSelectedEvent{BoardGenerator: boardGenerator},
+		event:    BoardGeneratorSelectedEvent{BoardGenerator: boardGenerator, Seed: seedOf(boardGenerator)},
 		headers:  nil,
 		version:  gameStateNew.game.Version(),
 		occurred: occurred,
@@ -38,7 +39,7 @@ func (gameStateNew *GameStateNew) SetBoardGenerator(boardGenerator BoardGenerato
 func (gameStateNew *GameStateNew) SetPlayersShuffler(playersShuffler PlayersShuffler, occurred time.Time) error {
 	eventMessage := EventDescriptor{
 		id:       gameStateNew.game.Id(),
-		event:    PlayersShufflerSelectedEvent{PlayersShuffler: playersShuffler},
+		event:    PlayersShufflerSelectedEvent{PlayersShuffler: playersShuffler, Seed: seedOf(playersShuffler)},
 		headers:  nil,
 		version:  gameStateNew.game.Version(),
 		occurred: occurred,
@@ -51,7 +52,55 @@ func (gameStateNew *GameStateNew) SetPlayersShuffler(playersShuffler PlayersShuf
 func (gameStateNew *GameStateNew) SetDiceRoller(diceRoller DiceRoller, occurred time.Time) error {
 	eventMessage := EventDescriptor{
 		id:       gameStateNew.game.Id(),
-		event:    DiceRollerSelected{DiceRoller: diceRoller},
+		event:    DiceRollerSelected{DiceRoller: diceRoller, Seed: seedOf(diceRoller)},
+		headers:  nil,
+		version:  gameStateNew.game.Version(),
+		occurred: occurred,
+	}
+
+	gameStateNew.game.Apply(eventMessage, true)
+	return nil
+}
+
+func (gameStateNew *GameStateNew) SetDevelopmentCardsShuffler(developmentCardsShuffler DevelopmentCardsShuffler, occurred time.Time) error {
+	eventMessage := EventDescriptor{
+		id:       gameStateNew.game.Id(),
+		event:    DevelopmentCardsShufflerSelectedEvent{DevelopmentCardsShuffler: developmentCardsShuffler, Seed: seedOf(developmentCardsShuffler)},
+		headers:  nil,
+		version:  gameStateNew.game.Version(),
+		occurred: occurred,
+	}
+
+	gameStateNew.game.Apply(eventMessage, true)
+	return nil
+}
+
+func (gameStateNew *GameStateNew) SetResourcePicker(resourcePicker ResourcePicker, occurred time.Time) error {
+	eventMessage := EventDescriptor{
+		id:       gameStateNew.game.Id(),
+		event:    ResourcePickerSelectedEvent{ResourcePicker: resourcePicker, Seed: seedOf(resourcePicker)},
+		headers:  nil,
+		version:  gameStateNew.game.Version(),
+		occurred: occurred,
+	}
+
+	gameStateNew.game.Apply(eventMessage, true)
+	return nil
+}
+
+// SetGameRules replaces all the rules selected before
+func (gameStateNew *GameStateNew) SetGameRules(rules GameRules, occurred time.Time) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	if int64(len(gameStateNew.game.Players())) > rules.MaxPlayers {
+		return GameIsFullErr
+	}
+
+	eventMessage := EventDescriptor{
+		id:       gameStateNew.game.Id(),
+		event:    GameRulesSelectedEvent{GameRules: rules},
 		headers:  nil,
 		version:  gameStateNew.game.Version(),
 		occurred: occurred,
@@ -62,7 +111,9 @@ func (gameStateNew *GameStateNew) SetDiceRoller(diceRoller DiceRoller, occurred
 }
 
 func (gameStateNew GameStateNew) AddPlayer(player Player, occurred time.Time) error {
-	// todo game is full condition
+	if int64(len(gameStateNew.game.Players())) >= gameStateNew.game.Rules().MaxPlayers {
+		return GameIsFullErr
+	}
 
 	eventMessage := EventDescriptor{
 		id:       gameStateNew.game.Id(),
@@ -97,6 +148,14 @@ func (gameStateNew *GameStateNew) StartGame(occurred time.Time) error {
 		return NoPlayersErr
 	}
 
+	if int64(len(game.Players())) < game.Rules().MinPlayers {
+		return NotEnoughPlayersErr
+	}
+
+	if int64(len(game.Players())) > game.Rules().MaxPlayers {
+		return GameIsFullErr
+	}
+
 	if game.PlayersShuffler() == nil {
 		return PlayersShufflerIsNotSelectedErr
 	}
@@ -105,6 +164,10 @@ func (gameStateNew *GameStateNew) StartGame(occurred time.Time) error {
 		return BoardGeneratorIsNotSelectedErr
 	}
 
+	if game.DiceRoller() == nil {
+		return DiceRollerIsNotSelectedErr
+	}
+
 	gameStartedEventMessage := NewEventDescriptor(
 		game.Id(),
 		GameStartedEvent{},
@@ -133,7 +196,22 @@ func (gameStateNew *GameStateNew) Apply(eventMessage EventMessage, _ bool) {
 		game.setPlayersShuffler(event.PlayersShuffler)
 	case DiceRollerSelected:
 		game.setDiceRoller(event.DiceRoller)
+	case DevelopmentCardsShufflerSelectedEvent:
+		game.setDevelopmentCardsShuffler(event.DevelopmentCardsShuffler)
+	case ResourcePickerSelectedEvent:
+		game.setResourcePicker(event.ResourcePicker)
+	case GameRulesSelectedEvent:
+		game.setRules(event.GameRules)
 	case GameStartedEvent:
+		// players get pieces the rules give
+		for _, player := range game.Players() {
+			player.setPieces(game.Rules())
+
+			if err := game.updatePlayer(player); err != nil {
+				panic(err)
+			}
+		}
+
 		game.setState(game.stateStarted)
 	}
 }
@@ -9,16 +9,46 @@ import (
 type GameStatePlay struct {
 	game *Game
 
-	currentSubState GameState
+	currentSubState                GameState
+	statePlayerIsRollingDice       GameState
+	statePlayersAreDiscarding      *GameStatePlayersAreDiscardingResources
+	statePlayerIsPlacingRobber     GameState
+	statePlayerSelectingWhoToRob   GameState
+	statePlayerIsPlacingSettlement GameState
+	statePlayerIsPlacingRoad       GameState
+
+	// knight can be played before rolling dice, the turn goes on from there when the robber is moved
+	stateAfterRobbing GameState
+
+	// road building can be played before rolling dice, the turn goes on from there when free roads are placed
+	stateAfterPlacingFreeRoads GameState
+	freeRoadsToPlace           int64
+
+	// players build one by one between turns, the first of them plays the next turn
+	specialBuildingPhase            bool
+	specialBuilders                 []Color
+	playerColorAfterSpecialBuilding Color
 
 	GameStateDefault
 }
 
 func NewGameStatePlay(
 	game *Game,
+	statePlayerIsRollingDice GameState,
+	statePlayersAreDiscarding *GameStatePlayersAreDiscardingResources,
+	statePlayerIsPlacingRobber GameState,
+	statePlayerSelectingWhoToRob GameState,
+	statePlayerIsPlacingSettlement GameState,
+	statePlayerIsPlacingRoad GameState,
 ) *GameStatePlay {
 	return &GameStatePlay{
-		game: game,
+		game:                           game,
+		statePlayerIsRollingDice:       statePlayerIsRollingDice,
+		statePlayersAreDiscarding:      statePlayersAreDiscarding,
+		statePlayerIsPlacingRobber:     statePlayerIsPlacingRobber,
+		statePlayerSelectingWhoToRob:   statePlayerSelectingWhoToRob,
+		statePlayerIsPlacingSettlement: statePlayerIsPlacingSettlement,
+		statePlayerIsPlacingRoad:       statePlayerIsPlacingRoad,
 	}
 }
 
@@ -44,7 +74,307 @@ func (gameStatePlay *GameStatePlay) EnterState(occurred time.Time) {
 	game.Apply(playerStartedHisTurnEventMessage, true)
 }
 
+func (gameStatePlay *GameStatePlay) RollDice(playerColor Color, occurred time.Time) error {
+	if gameStatePlay.currentSubState == nil {
+		return CommandIsForbiddenErr
+	}
+
+	return gameStatePlay.currentSubState.RollDice(playerColor, occurred)
+}
+
+func (gameStatePlay *GameStatePlay) DiscardResources(playerColor Color, resources []ResourceCard, occurred time.Time) error {
+	if gameStatePlay.currentSubState == nil {
+		return CommandIsForbiddenErr
+	}
+
+	return gameStatePlay.currentSubState.DiscardResources(playerColor, resources, occurred)
+}
+
+func (gameStatePlay *GameStatePlay) PlaceRobber(playerColor Color, hexCoord grid.HexCoord, occurred time.Time) error {
+	if gameStatePlay.currentSubState == nil {
+		return CommandIsForbiddenErr
+	}
+
+	if err := gameStatePlay.currentSubState.PlaceRobber(playerColor, hexCoord, occurred); err != nil {
+		return err
+	}
+
+	// the only player next to the robber is robbed at once
+	gameStatePlay.invalidateTradeOffers(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) RobPlayer(playerColor Color, targetColor Color, occurred time.Time) error {
+	if gameStatePlay.currentSubState == nil {
+		return CommandIsForbiddenErr
+	}
+
+	if err := gameStatePlay.currentSubState.RobPlayer(playerColor, targetColor, occurred); err != nil {
+		return err
+	}
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) PlayDevelopmentCard(playerColor Color, card DevelopmentCard, occurred time.Time) error {
+	if err := gameStatePlay.canPlayDevelopmentCard(playerColor, card); err != nil {
+		return err
+	}
+
+	switch card.Type() {
+	case Knight:
+		if err := gameStatePlay.playKnight(playerColor, occurred); err != nil {
+			return err
+		}
+
+		// largest army can bring the victory
+		gameStatePlay.checkVictory(occurred)
+
+		return nil
+	case RoadBuilding:
+		return gameStatePlay.playRoadBuilding(playerColor, occurred)
+	default:
+		// year of plenty and monopoly have their own commands, victory points are never played
+		return CommandIsForbiddenErr
+	}
+}
+
+// canPlayDevelopmentCard checks the card can be played now, it can be played before rolling dice as well
+func (gameStatePlay *GameStatePlay) canPlayDevelopmentCard(playerColor Color, card DevelopmentCard) error {
+	if gameStatePlay.currentSubState != nil && gameStatePlay.currentSubState != gameStatePlay.statePlayerIsRollingDice {
+		return CommandIsForbiddenErr
+	}
+
+	if gameStatePlay.specialBuildingPhase {
+		return CommandIsForbiddenErr
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	return player.CanPlayDevelopmentCard(card)
+}
+
+func (gameStatePlay *GameStatePlay) playRoadBuilding(playerColor Color, occurred time.Time) error {
+	game := gameStatePlay.game
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.HasAvailableRoad(); err != nil {
+		return err
+	}
+
+	// free roads cannot be skipped, so the player must be able to place at least one
+	if !gameStatePlay.canPlaceAnyRoad(playerColor) {
+		return NoPlaceForRoadErr
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPlayedDevelopmentCardEvent{
+				PlayerColor:     playerColor,
+				DevelopmentCard: DevelopmentCardRoadBuilding,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) PlayYearOfPlenty(playerColor Color, resources []ResourceCard, occurred time.Time) error {
+	if err := gameStatePlay.canPlayDevelopmentCard(playerColor, DevelopmentCardYearOfPlenty); err != nil {
+		return err
+	}
+
+	if len(resources) != YearOfPlentyResources {
+		return WrongNumberOfResourcesToTakeErr
+	}
+
+	game := gameStatePlay.game
+
+	if err := game.bankHasResources(resources); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPlayedDevelopmentCardEvent{
+				PlayerColor:     playerColor,
+				DevelopmentCard: DevelopmentCardYearOfPlenty,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPickedResourcesEvent{
+				PlayerColor:     playerColor,
+				PickedResources: resources,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) PlayMonopoly(playerColor Color, resource Resource, occurred time.Time) error {
+	if err := gameStatePlay.canPlayDevelopmentCard(playerColor, DevelopmentCardMonopoly); err != nil {
+		return err
+	}
+
+	if resource == EmptyResource {
+		return CommandIsForbiddenErr
+	}
+
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPlayedDevelopmentCardEvent{
+				PlayerColor:     playerColor,
+				DevelopmentCard: DevelopmentCardMonopoly,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	collectedResources := make(map[Color]int64)
+
+	for _, opponent := range game.Players() {
+		if opponent.Color() == playerColor {
+			continue
+		}
+
+		if count := opponent.ResourceCount(resource); count > 0 {
+			collectedResources[opponent.Color()] = count
+		}
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerCollectedMonopolyResourcesEvent{
+				PlayerColor:        playerColor,
+				Resource:           resource,
+				CollectedResources: collectedResources,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) playKnight(playerColor Color, occurred time.Time) error {
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPlayedDevelopmentCardEvent{
+				PlayerColor:     playerColor,
+				DevelopmentCard: DevelopmentCardKnight,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	largestArmyOwnerColor := None
+	largestArmy := int64(LargestArmyKnights - 1)
+
+	for _, opponent := range game.Players() {
+		if opponent.LargestArmyOwner() {
+			largestArmyOwnerColor = opponent.Color()
+			largestArmy = opponent.PlayedKnights()
+		}
+	}
+
+	// the owner keeps largest army on a tie
+	if largestArmyOwnerColor == playerColor || player.PlayedKnights() <= largestArmy {
+		return nil
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			LargestArmyOwnerChangedEvent{
+				PlayerColor:        playerColor,
+				PreviousOwnerColor: largestArmyOwnerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
 func (gameStatePlay *GameStatePlay) PlaceSettlement(playerColor Color, settlement Settlement, occurred time.Time) error {
+	if err := gameStatePlay.placeSettlement(playerColor, settlement, occurred); err != nil {
+		return err
+	}
+
+	// the settlement can break the longest road
+	gameStatePlay.updateLongestRoadOwner(occurred)
+	gameStatePlay.invalidateTradeOffers(occurred)
+	gameStatePlay.checkVictory(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) placeSettlement(playerColor Color, settlement Settlement, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.PlaceSettlement(playerColor, settlement, occurred)
+	}
+
 	game := gameStatePlay.game
 
 	player, err := game.Player(playerColor)
@@ -56,6 +386,10 @@ func (gameStatePlay *GameStatePlay) PlaceSettlement(playerColor Color, settlemen
 		return WrongTurnErr
 	}
 
+	if settlement.Color() != playerColor {
+		return WrongPieceColorErr
+	}
+
 	if err := player.CanBuy(settlement); err != nil {
 		return err
 	}
@@ -64,6 +398,14 @@ func (gameStatePlay *GameStatePlay) PlaceSettlement(playerColor Color, settlemen
 		return err
 	}
 
+	if err := canBuildSettlement(game.Board(), settlement); err != nil {
+		return err
+	}
+
+	if !isConnectedToRoad(game.Board(), playerColor, settlement.IntersectionCoord()) {
+		return SettlementIsNotConnectedErr
+	}
+
 	playerBuiltSettlementEventMessage := NewEventDescriptor(
 		game.Id(),
 		PlayerPlacedSettlementEvent{
@@ -80,13 +422,100 @@ func (gameStatePlay *GameStatePlay) PlaceSettlement(playerColor Color, settlemen
 	return nil
 }
 
+func (gameStatePlay *GameStatePlay) PlaceCity(playerColor Color, city City, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.PlaceCity(playerColor, city, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if city.Color() != playerColor {
+		return WrongPieceColorErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.CanBuildCity(); err != nil {
+		return err
+	}
+
+	if err := gameStatePlay.canUpgradeSettlement(city); err != nil {
+		return err
+	}
+
+	if err := player.CanBuy(city); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerPlacedCityEvent{
+				PlayerColor: playerColor,
+				City:        city,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+	gameStatePlay.checkVictory(occurred)
+
+	return nil
+}
+
+// canUpgradeSettlement checks the city is placed on the player's own settlement
+func (gameStatePlay *GameStatePlay) canUpgradeSettlement(city City) error {
+	intersection, exists := gameStatePlay.game.Board().Intersection(city.IntersectionCoord())
+	if !exists {
+		return BadIntersectionCoordErr
+	}
+
+	settlement, isSettlement := intersection.Building().(Settlement)
+	if !isSettlement || settlement.Color() != city.Color() {
+		return NoSettlementToUpgradeErr
+	}
+
+	return nil
+}
+
 func (gameStatePlay *GameStatePlay) PlaceRoad(playerColor Color, road Road, occurred time.Time) error {
+	if err := gameStatePlay.placeRoad(playerColor, road, occurred); err != nil {
+		return err
+	}
+
+	gameStatePlay.updateLongestRoadOwner(occurred)
+	gameStatePlay.invalidateTradeOffers(occurred)
+	gameStatePlay.checkVictory(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) placeRoad(playerColor Color, road Road, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.PlaceRoad(playerColor, road, occurred)
+	}
+
 	game := gameStatePlay.game
 
 	if game.CurrentTurn() != playerColor {
 		return WrongTurnErr
 	}
 
+	if road.color != playerColor {
+		return WrongPieceColorErr
+	}
+
 	player, err := game.Player(playerColor)
 	if err != nil {
 		return err
@@ -107,7 +536,7 @@ func (gameStatePlay *GameStatePlay) PlaceRoad(playerColor Color, road Road, occu
 		return err
 	}
 
-	gameStatePlay.Apply(
+	game.Apply(
 		NewEventDescriptor(game.Id(), PlayerPlacedRoadEvent{
 			PlayerColor: playerColor,
 			Road:        road,
@@ -119,6 +548,17 @@ func (gameStatePlay *GameStatePlay) PlaceRoad(playerColor Color, road Road, occu
 	return nil
 }
 
+// canPlaceAnyRoad checks the board has a path the player can build a road on
+func (gameStatePlay *GameStatePlay) canPlaceAnyRoad(playerColor Color) bool {
+	for _, pathCoord := range boardPathCoords(gameStatePlay.game.Board()) {
+		if gameStatePlay.canBuildRoad(pathCoord, NewRoad(pathCoord, playerColor)) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (gameStatePlay *GameStatePlay) canBuildRoad(pathCoord grid.PathCoord, road Road) error {
 	game := gameStatePlay.game
 
@@ -162,7 +602,7 @@ func (gameStatePlay *GameStatePlay) canBuildRoad(pathCoord grid.PathCoord, road
 			continue
 		}
 
-		if !adjacentPath.IsEmpty() {
+		if adjacentPath.IsEmpty() || adjacentPath.Road().color != road.color {
 			continue
 		}
 
@@ -172,7 +612,7 @@ func (gameStatePlay *GameStatePlay) canBuildRoad(pathCoord grid.PathCoord, road
 		}
 
 		intersection, exists := game.Board().Intersection(jointIntersectionCoord)
-		if exists {
+		if !exists {
 			continue
 		}
 
@@ -191,39 +631,353 @@ func (gameStatePlay *GameStatePlay) canBuildRoad(pathCoord grid.PathCoord, road
 	return CommandIsForbiddenErr
 }
 
-func (gameStatePlay *GameStatePlay) BuyDevelopmentCard(playerColor Color) error {
-	panic("implement me")
+func (gameStatePlay *GameStatePlay) BuyDevelopmentCard(playerColor Color, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.BuyDevelopmentCard(playerColor, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if game.AvailableDevelopmentCards() == 0 {
+		return NoDevelopmentCardsLeftErr
+	}
+
+	developmentCard := game.developmentCardsDeck[0]
+
+	if err := player.CanBuy(developmentCard); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerBoughtDevelopmentCardEvent{
+				PlayerColor:     playerColor,
+				DevelopmentCard: developmentCard,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+	gameStatePlay.checkVictory(occurred)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) MaritimeTrade(playerColor Color, givenResource ResourceCard, takenResource ResourceCard, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.MaritimeTrade(playerColor, givenResource, takenResource, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if !game.Rules().MaritimeTradeAllowed || gameStatePlay.specialBuildingPhase {
+		return TradeIsNotAllowedErr
+	}
+
+	if givenResource == takenResource {
+		return BadTradeErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	tradeRate := MaritimeTradeRate(game.Board(), playerColor, givenResource)
+	givenResources := givenResource.resource.GetResourceCard(tradeRate)
+
+	if err := player.HasResources(givenResources); err != nil {
+		return err
+	}
+
+	if err := game.bankHasResources([]ResourceCard{takenResource}); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerTradedWithBankEvent{
+				PlayerColor:    playerColor,
+				GivenResources: givenResources,
+				TakenResources: []ResourceCard{takenResource},
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+
+	return nil
 }
 
+// EndTurn passes the turn to the next player, the turn can be ended only after rolling dice
 func (gameStatePlay *GameStatePlay) EndTurn(playerColor Color, occurred time.Time) error {
-	panic("implement me")
+	if gameStatePlay.currentSubState != nil {
+		return CommandIsForbiddenErr
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if gameStatePlay.specialBuildingPhase {
+		gameStatePlay.endSpecialBuilding(playerColor, occurred)
+		return nil
+	}
+
+	nextPlayerColor := game.NextTurnColor()
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerFinishedHisTurnEvent{
+				PlayerColor: playerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	// the phase is skipped when nobody else can build
+	if specialBuilders := gameStatePlay.specialBuildersAfter(playerColor); game.Rules().SpecialBuildingPhase && len(specialBuilders) > 0 {
+		gameStatePlay.startSpecialBuildingPhase(specialBuilders, occurred)
+		return nil
+	}
+
+	gameStatePlay.startTurn(nextPlayerColor, occurred)
+
+	return nil
+}
+
+// startTurn lets the player roll dice, he wins right away if he got enough victory points out of his turn
+func (gameStatePlay *GameStatePlay) startTurn(playerColor Color, occurred time.Time) {
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerStartedHisTurnEvent{
+				PlayerColor: playerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.checkVictory(occurred)
 }
 
 func (gameStatePlay *GameStatePlay) CurrentTurn() Color {
-	panic("implement me")
+	return gameStatePlay.game.CurrentTurn()
 }
 
 func (gameStatePlay *GameStatePlay) TurnOrder() []Color {
 	return gameStatePlay.game.turnOrder
 }
 
+// placeRobberAfterDiscards waits until all players over the hand limit discard, then lets the current player place the robber
+func (gameStatePlay *GameStatePlay) placeRobberAfterDiscards() {
+	if !gameStatePlay.statePlayersAreDiscarding.isFinished() {
+		gameStatePlay.currentSubState = gameStatePlay.statePlayersAreDiscarding
+		return
+	}
+
+	gameStatePlay.currentSubState = gameStatePlay.statePlayerIsPlacingRobber
+}
+
+// checkVictory finishes the game when the current player has enough victory points including hidden ones
+func (gameStatePlay *GameStatePlay) checkVictory(occurred time.Time) {
+	game := gameStatePlay.game
+
+	// the game can be won only on the player's own turn
+	if gameStatePlay.specialBuildingPhase {
+		return
+	}
+
+	player, err := game.Player(game.CurrentTurn())
+	if err != nil {
+		return
+	}
+
+	if player.TotalVictoryPoints() < game.VictoryPointsToWin() {
+		return
+	}
+
+	finalScores := make(map[Color]int64)
+
+	for _, scoredPlayer := range game.Players() {
+		finalScores[scoredPlayer.Color()] = scoredPlayer.TotalVictoryPoints()
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			GameFinishedEvent{
+				WinnerColor: player.Color(),
+				FinalScores: finalScores,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+}
+
+// updateLongestRoadOwner gives longest road to the only player with the longest road of five or more,
+// the owner keeps it on a tie and loses it when his road is broken and nobody else has the longest road alone
+func (gameStatePlay *GameStatePlay) updateLongestRoadOwner(occurred time.Time) {
+	game := gameStatePlay.game
+
+	ownerColor := None
+	var ownerLongestRoad int64
+
+	var longestRoad int64
+	var longestRoadColors []Color
+
+	for _, player := range game.Players() {
+		if player.LongestRoadOwner() {
+			ownerColor = player.Color()
+			ownerLongestRoad = player.LongestRoad()
+		}
+
+		switch {
+		case player.LongestRoad() > longestRoad:
+			longestRoad = player.LongestRoad()
+			longestRoadColors = []Color{player.Color()}
+		case player.LongestRoad() == longestRoad:
+			longestRoadColors = append(longestRoadColors, player.Color())
+		}
+	}
+
+	switch {
+	case ownerColor != None && ownerLongestRoad == longestRoad && longestRoad >= LongestRoadMinLength:
+		// the owner keeps longest road on a tie
+	case longestRoad >= LongestRoadMinLength && len(longestRoadColors) == 1:
+		game.Apply(
+			NewEventDescriptor(
+				game.Id(),
+				LongestRoadOwnerChangedEvent{
+					PlayerColor:        longestRoadColors[0],
+					PreviousOwnerColor: ownerColor,
+				},
+				nil,
+				game.Version(),
+				occurred,
+			),
+			true,
+		)
+	case ownerColor != None:
+		// the owner's road is broken and nobody else has the longest road alone
+		game.Apply(
+			NewEventDescriptor(
+				game.Id(),
+				LongestRoadLostEvent{
+					PlayerColor: ownerColor,
+				},
+				nil,
+				game.Version(),
+				occurred,
+			),
+			true,
+		)
+	}
+}
+
+// takeLongestRoad takes longest road and its victory points from the player
+func (gameStatePlay *GameStatePlay) takeLongestRoad(playerColor Color) {
+	game := gameStatePlay.game
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		panic(err)
+	}
+
+	player.longestRoadOwner = false
+	player.victoryPoints -= LongestRoadVictoryPoints
+
+	err = game.updatePlayer(player)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// placeFreeRoad counts free roads of road building,
+// the turn goes on when all of them are placed or the player is out of roads or has no place for them
+func (gameStatePlay *GameStatePlay) placeFreeRoad(playerColor Color) {
+	gameStatePlay.freeRoadsToPlace--
+
+	player, err := gameStatePlay.game.Player(playerColor)
+	if err != nil {
+		panic(err)
+	}
+
+	if gameStatePlay.freeRoadsToPlace > 0 && player.HasAvailableRoad() == nil && gameStatePlay.canPlaceAnyRoad(playerColor) {
+		return
+	}
+
+	gameStatePlay.freeRoadsToPlace = 0
+	gameStatePlay.currentSubState = gameStatePlay.stateAfterPlacingFreeRoads
+}
+
 func (gameStatePlay *GameStatePlay) Apply(eventMessage EventMessage, isNew bool) {
 	game := gameStatePlay.game
 
 	switch event := eventMessage.Event().(type) {
 	case PlayerStartedHisTurnEvent:
 		game.setCurrentTurn(event.PlayerColor)
+		gameStatePlay.currentSubState = gameStatePlay.statePlayerIsRollingDice
 	case PlayerFinishedHisTurnEvent:
 		game.incrementTotalTurns()
 		game.setCurrentTurn(None)
 
-		// todo invoke next player start his turn
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.makeNewDevelopmentCardsPlayable()
+		game.tradeOffers = nil
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
 	case PlayerPlacedSettlementEvent:
 		player, err := game.Player(event.PlayerColor)
 		if err != nil {
 			panic(err)
 		}
 
+		player = game.buy(player, event.Settlement)
 		player.victoryPoints += event.Settlement.VictoryPoints()
 		player.availableSettlements--
 
@@ -236,12 +990,68 @@ func (gameStatePlay *GameStatePlay) Apply(eventMessage EventMessage, isNew bool)
 		if err != nil {
 			panic(err)
 		}
+
+		game.updateLongestRoads()
+	case SpecialBuildingPhaseStartedEvent, PlayerStartedSpecialBuildingEvent, PlayerFinishedSpecialBuildingEvent, SpecialBuildingPhaseFinishedEvent:
+		gameStatePlay.applySpecialBuilding(eventMessage)
+	case TradeOfferedEvent, TradeOfferAcceptedEvent, TradeOfferRejectedEvent, TradeOfferCounteredEvent, TradeConfirmedEvent, TradeOfferInvalidatedEvent:
+		gameStatePlay.applyTrade(eventMessage)
+	case PlayerTradedWithBankEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player = player.WithDisposedResources(event.GivenResources)
+		player.GainResources(event.TakenResources)
+
+		game.returnToBank(event.GivenResources)
+		game.takeFromBank(event.TakenResources)
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	case PlayerPlacedCityEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		intersection, exists := game.Board().Intersection(event.City.IntersectionCoord())
+		if !exists {
+			panic(BadIntersectionCoordErr)
+		}
+
+		// the settlement piece returns to the player's supply
+		player = game.buy(player, event.City)
+		player.victoryPoints += event.City.VictoryPoints() - intersection.Building().VictoryPoints()
+		player.availableCities--
+		player.availableSettlements++
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+
+		err = game.placeCity(event.City)
+		if err != nil {
+			panic(err)
+		}
 	case PlayerPlacedRoadEvent:
+		if gameStatePlay.currentSubState == gameStatePlay.statePlayerIsPlacingRoad {
+			gameStatePlay.currentSubState.Apply(eventMessage, isNew)
+			game.updateLongestRoads()
+			gameStatePlay.placeFreeRoad(event.PlayerColor)
+			break
+		}
+
 		player, err := game.Player(event.PlayerColor)
 		if err != nil {
 			panic(err)
 		}
 
+		player = game.buy(player, event.Road)
 		player.availableRoads--
 
 		err = game.updatePlayer(player)
@@ -249,10 +1059,12 @@ func (gameStatePlay *GameStatePlay) Apply(eventMessage EventMessage, isNew bool)
 			panic(err)
 		}
 
-		//game.Board().BuildRoad(event.PathCoord, event.Road)
-	case PlayerWasRobbedByRobberEvent:
-		// todo
-	case PlayerWasRobbedByPlayerEvent:
+		err = game.placeRoad(event.Road)
+		if err != nil {
+			panic(err)
+		}
+
+		game.updateLongestRoads()
 	case PlayerPickedResourcesEvent:
 		player, err := game.Player(event.PlayerColor)
 		if err != nil {
@@ -260,11 +1072,143 @@ func (gameStatePlay *GameStatePlay) Apply(eventMessage EventMessage, isNew bool)
 		}
 
 		player.GainResources(event.PickedResources)
+		game.takeFromBank(event.PickedResources)
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	case PlayerBoughtDevelopmentCardEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player = game.buy(player, event.DevelopmentCard)
+		player.addDevelopmentCard(event.DevelopmentCard)
+
 		err = game.updatePlayer(player)
 		if err != nil {
 			panic(err)
 		}
+
+		game.setDevelopmentCardsDeck(game.developmentCardsDeck[1:])
 	case PlayerRolledDiceEvent:
-		game.rollHistory = append(game.rollHistory, event.Roll)
+		gameStatePlay.currentSubState.Apply(eventMessage, isNew)
+		gameStatePlay.currentSubState = nil
+
+		if event.Roll.IsRobber() {
+			gameStatePlay.stateAfterRobbing = nil
+			gameStatePlay.statePlayersAreDiscarding.start()
+			gameStatePlay.placeRobberAfterDiscards()
+		}
+	case PlayerWasRobbedByRobberEvent:
+		gameStatePlay.currentSubState.Apply(eventMessage, isNew)
+		gameStatePlay.placeRobberAfterDiscards()
+	case PlayerMovedRobberEvent:
+		gameStatePlay.currentSubState.Apply(eventMessage, isNew)
+		gameStatePlay.currentSubState = gameStatePlay.stateAfterRobbing
+
+		if len(playersToRob(game, event.PlayerColor)) > 0 {
+			gameStatePlay.currentSubState = gameStatePlay.statePlayerSelectingWhoToRob
+		}
+	case PlayerWasRobbedByPlayerEvent:
+		gameStatePlay.currentSubState.Apply(eventMessage, isNew)
+		gameStatePlay.currentSubState = gameStatePlay.stateAfterRobbing
+	case PlayerPlayedDevelopmentCardEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.playDevelopmentCard(event.DevelopmentCard)
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+
+		switch event.DevelopmentCard.Type() {
+		case Knight:
+			gameStatePlay.stateAfterRobbing = gameStatePlay.currentSubState
+			gameStatePlay.currentSubState = gameStatePlay.statePlayerIsPlacingRobber
+		case RoadBuilding:
+			gameStatePlay.stateAfterPlacingFreeRoads = gameStatePlay.currentSubState
+			gameStatePlay.currentSubState = gameStatePlay.statePlayerIsPlacingRoad
+			gameStatePlay.freeRoadsToPlace = RoadBuildingRoads
+		}
+	case PlayerCollectedMonopolyResourcesEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		for opponentColor, count := range event.CollectedResources {
+			opponent, err := game.Player(opponentColor)
+			if err != nil {
+				panic(err)
+			}
+
+			collectedResources := event.Resource.GetResourceCard(count)
+
+			opponent = opponent.WithDisposedResources(collectedResources)
+			player.GainResources(collectedResources)
+
+			err = game.updatePlayer(opponent)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	case LongestRoadOwnerChangedEvent:
+		if event.PreviousOwnerColor != None {
+			gameStatePlay.takeLongestRoad(event.PreviousOwnerColor)
+		}
+
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.longestRoadOwner = true
+		player.victoryPoints += LongestRoadVictoryPoints
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
+	case LongestRoadLostEvent:
+		gameStatePlay.takeLongestRoad(event.PlayerColor)
+	case LargestArmyOwnerChangedEvent:
+		if event.PreviousOwnerColor != None {
+			previousOwner, err := game.Player(event.PreviousOwnerColor)
+			if err != nil {
+				panic(err)
+			}
+
+			previousOwner.largestArmyOwner = false
+			previousOwner.victoryPoints -= LargestArmyVictoryPoints
+
+			err = game.updatePlayer(previousOwner)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.largestArmyOwner = true
+		player.victoryPoints += LargestArmyVictoryPoints
+
+		err = game.updatePlayer(player)
+		if err != nil {
+			panic(err)
+		}
 	}
 }
@@ -0,0 +1,135 @@
+package domain
+
+import "time"
+
+// specialBuildersAfter returns other players in the turn order starting from the one after the player
+func (gameStatePlay *GameStatePlay) specialBuildersAfter(playerColor Color) []Color {
+	game := gameStatePlay.game
+
+	var specialBuilders []Color
+
+	for i, color := range game.turnOrder {
+		if color != playerColor {
+			continue
+		}
+
+		for j := 1; j < len(game.turnOrder); j++ {
+			specialBuilders = append(specialBuilders, game.turnOrder[(i+j)%len(game.turnOrder)])
+		}
+	}
+
+	return specialBuilders
+}
+
+// startSpecialBuildingPhase lets other players build in the turn order, there must be at least one of them
+func (gameStatePlay *GameStatePlay) startSpecialBuildingPhase(specialBuilders []Color, occurred time.Time) {
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			SpecialBuildingPhaseStartedEvent{
+				PlayerColors: specialBuilders,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.startSpecialBuilding(occurred)
+}
+
+func (gameStatePlay *GameStatePlay) startSpecialBuilding(occurred time.Time) {
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerStartedSpecialBuildingEvent{
+				PlayerColor: gameStatePlay.specialBuilders[0],
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+}
+
+// endSpecialBuilding passes building to the next player, the next turn starts when everybody has built
+func (gameStatePlay *GameStatePlay) endSpecialBuilding(playerColor Color, occurred time.Time) {
+	game := gameStatePlay.game
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerFinishedSpecialBuildingEvent{
+				PlayerColor: playerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	if len(gameStatePlay.specialBuilders) > 0 {
+		gameStatePlay.startSpecialBuilding(occurred)
+		return
+	}
+
+	nextPlayerColor := gameStatePlay.playerColorAfterSpecialBuilding
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			SpecialBuildingPhaseFinishedEvent{},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.startTurn(nextPlayerColor, occurred)
+}
+
+func (gameStatePlay *GameStatePlay) applySpecialBuilding(eventMessage EventMessage) {
+	game := gameStatePlay.game
+
+	switch event := eventMessage.Event().(type) {
+	case SpecialBuildingPhaseStartedEvent:
+		// nobody builds, the phase is not entered
+		if len(event.PlayerColors) == 0 {
+			break
+		}
+
+		gameStatePlay.specialBuildingPhase = true
+		gameStatePlay.specialBuilders = event.PlayerColors
+		gameStatePlay.playerColorAfterSpecialBuilding = event.PlayerColors[0]
+	case PlayerStartedSpecialBuildingEvent:
+		game.setCurrentTurn(event.PlayerColor)
+		gameStatePlay.currentSubState = nil
+	case PlayerFinishedSpecialBuildingEvent:
+		game.setCurrentTurn(None)
+		gameStatePlay.specialBuilders = gameStatePlay.specialBuilders[1:]
+
+		// cards bought out of the player's turn can be played on his next turn
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player.makeNewDevelopmentCardsPlayable()
+
+		if err := game.updatePlayer(player); err != nil {
+			panic(err)
+		}
+	case SpecialBuildingPhaseFinishedEvent:
+		gameStatePlay.specialBuildingPhase = false
+		gameStatePlay.specialBuilders = nil
+		gameStatePlay.playerColorAfterSpecialBuilding = None
+	}
+}
@@ -0,0 +1,365 @@
+package domain
+
+import "time"
+
+func (gameStatePlay *GameStatePlay) ProposeTrade(playerColor Color, givenResources []ResourceCard, takenResources []ResourceCard, targetColors []Color, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.ProposeTrade(playerColor, givenResources, takenResources, targetColors, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if !game.Rules().DomesticTradeAllowed || gameStatePlay.specialBuildingPhase {
+		return TradeIsNotAllowedErr
+	}
+
+	if !isValidTrade(givenResources, takenResources) {
+		return BadTradeErr
+	}
+
+	for _, targetColor := range targetColors {
+		if targetColor == playerColor {
+			return BadTradeErr
+		}
+
+		if _, err := game.Player(targetColor); err != nil {
+			return err
+		}
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.HasResources(givenResources); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			TradeOfferedEvent{
+				TradeOfferId:   game.lastTradeOfferId + 1,
+				PlayerColor:    playerColor,
+				GivenResources: givenResources,
+				TakenResources: takenResources,
+				TargetColors:   targetColors,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) AcceptTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.AcceptTradeOffer(playerColor, tradeOfferId, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	tradeOffer, err := gameStatePlay.tradeOfferToAnswer(playerColor, tradeOfferId)
+	if err != nil {
+		return err
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.HasResources(tradeOffer.TakenResources()); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			TradeOfferAcceptedEvent{
+				TradeOfferId: tradeOfferId,
+				PlayerColor:  playerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) RejectTradeOffer(playerColor Color, tradeOfferId int64, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.RejectTradeOffer(playerColor, tradeOfferId, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if _, err := gameStatePlay.tradeOfferToAnswer(playerColor, tradeOfferId); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			TradeOfferRejectedEvent{
+				TradeOfferId: tradeOfferId,
+				PlayerColor:  playerColor,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) CounterTradeOffer(playerColor Color, tradeOfferId int64, givenResources []ResourceCard, takenResources []ResourceCard, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.CounterTradeOffer(playerColor, tradeOfferId, givenResources, takenResources, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if _, err := gameStatePlay.tradeOfferToAnswer(playerColor, tradeOfferId); err != nil {
+		return err
+	}
+
+	if !isValidTrade(givenResources, takenResources) {
+		return BadTradeErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.HasResources(givenResources); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			TradeOfferCounteredEvent{
+				TradeOfferId:        tradeOfferId,
+				CounterTradeOfferId: game.lastTradeOfferId + 1,
+				PlayerColor:         playerColor,
+				GivenResources:      givenResources,
+				TakenResources:      takenResources,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (gameStatePlay *GameStatePlay) ConfirmTrade(playerColor Color, tradeOfferId int64, acceptedPlayerColor Color, occurred time.Time) error {
+	if gameStatePlay.currentSubState != nil {
+		return gameStatePlay.currentSubState.ConfirmTrade(playerColor, tradeOfferId, acceptedPlayerColor, occurred)
+	}
+
+	game := gameStatePlay.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	tradeOffer, exists := game.TradeOffer(tradeOfferId)
+	if !exists {
+		return TradeOfferNotFoundErr
+	}
+
+	if !tradeOffer.IsAcceptedBy(acceptedPlayerColor) {
+		return TradeOfferIsNotAcceptedErr
+	}
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := player.HasResources(tradeOffer.GivenResources()); err != nil {
+		return err
+	}
+
+	acceptedPlayer, err := game.Player(acceptedPlayerColor)
+	if err != nil {
+		return err
+	}
+
+	if err := acceptedPlayer.HasResources(tradeOffer.TakenResources()); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			TradeConfirmedEvent{
+				TradeOfferId:        tradeOfferId,
+				PlayerColor:         playerColor,
+				AcceptedPlayerColor: acceptedPlayerColor,
+				GivenResources:      tradeOffer.GivenResources(),
+				TakenResources:      tradeOffer.TakenResources(),
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	gameStatePlay.invalidateTradeOffers(occurred)
+
+	return nil
+}
+
+// tradeOfferToAnswer returns the offer the opponent can accept, reject or counter
+func (gameStatePlay *GameStatePlay) tradeOfferToAnswer(playerColor Color, tradeOfferId int64) (TradeOffer, error) {
+	tradeOffer, exists := gameStatePlay.game.TradeOffer(tradeOfferId)
+	if !exists {
+		return TradeOffer{}, TradeOfferNotFoundErr
+	}
+
+	if !tradeOffer.IsTargetedAt(playerColor) || tradeOffer.hasAnswered(playerColor) {
+		return TradeOffer{}, CommandIsForbiddenErr
+	}
+
+	return tradeOffer, nil
+}
+
+// invalidateTradeOffers closes offers the offering player's hand doesn't cover
+// and turns acceptances the accepting player's hand doesn't cover into rejections
+func (gameStatePlay *GameStatePlay) invalidateTradeOffers(occurred time.Time) {
+	game := gameStatePlay.game
+
+	for _, tradeOffer := range game.TradeOffers() {
+		player, err := game.Player(tradeOffer.PlayerColor())
+		if err != nil {
+			panic(err)
+		}
+
+		if player.HasResources(tradeOffer.GivenResources()) != nil {
+			game.Apply(
+				NewEventDescriptor(
+					game.Id(),
+					TradeOfferInvalidatedEvent{
+						TradeOfferId: tradeOffer.Id(),
+					},
+					nil,
+					game.Version(),
+					occurred,
+				),
+				true,
+			)
+
+			continue
+		}
+
+		for _, acceptedColor := range tradeOffer.AcceptedColors() {
+			acceptedPlayer, err := game.Player(acceptedColor)
+			if err != nil {
+				panic(err)
+			}
+
+			if acceptedPlayer.HasResources(tradeOffer.TakenResources()) == nil {
+				continue
+			}
+
+			game.Apply(
+				NewEventDescriptor(
+					game.Id(),
+					TradeOfferRejectedEvent{
+						TradeOfferId: tradeOffer.Id(),
+						PlayerColor:  acceptedColor,
+					},
+					nil,
+					game.Version(),
+					occurred,
+				),
+				true,
+			)
+		}
+	}
+}
+
+func (gameStatePlay *GameStatePlay) applyTrade(eventMessage EventMessage) {
+	game := gameStatePlay.game
+
+	switch event := eventMessage.Event().(type) {
+	case TradeOfferedEvent:
+		game.addTradeOffer(TradeOffer{
+			id:             event.TradeOfferId,
+			playerColor:    event.PlayerColor,
+			givenResources: event.GivenResources,
+			takenResources: event.TakenResources,
+			targetColors:   event.TargetColors,
+		})
+	case TradeOfferAcceptedEvent:
+		tradeOffer, _ := game.TradeOffer(event.TradeOfferId)
+		tradeOffer.accept(event.PlayerColor)
+		game.updateTradeOffer(tradeOffer)
+	case TradeOfferRejectedEvent:
+		tradeOffer, _ := game.TradeOffer(event.TradeOfferId)
+		tradeOffer.reject(event.PlayerColor)
+		game.updateTradeOffer(tradeOffer)
+	case TradeOfferCounteredEvent:
+		tradeOffer, _ := game.TradeOffer(event.TradeOfferId)
+		tradeOffer.reject(event.PlayerColor)
+		game.updateTradeOffer(tradeOffer)
+
+		// the counter offer is seen from the offering player side
+		game.addTradeOffer(TradeOffer{
+			id:             event.CounterTradeOfferId,
+			playerColor:    tradeOffer.PlayerColor(),
+			givenResources: event.TakenResources,
+			takenResources: event.GivenResources,
+			targetColors:   []Color{event.PlayerColor},
+			acceptedColors: []Color{event.PlayerColor},
+		})
+	case TradeConfirmedEvent:
+		player, err := game.Player(event.PlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		acceptedPlayer, err := game.Player(event.AcceptedPlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		player = player.WithDisposedResources(event.GivenResources)
+		player.GainResources(event.TakenResources)
+
+		acceptedPlayer = acceptedPlayer.WithDisposedResources(event.TakenResources)
+		acceptedPlayer.GainResources(event.GivenResources)
+
+		if err := game.updatePlayer(player); err != nil {
+			panic(err)
+		}
+
+		if err := game.updatePlayer(acceptedPlayer); err != nil {
+			panic(err)
+		}
+
+		game.removeTradeOffer(event.TradeOfferId)
+	case TradeOfferInvalidatedEvent:
+		game.removeTradeOffer(event.TradeOfferId)
+	}
+}
@@ -21,6 +21,10 @@ func (gameStatePlayerIsToPlaceRoad *GameStatePlayerIsPlacingRoad) PlaceRoad(play
 		return WrongTurnErr
 	}
 
+	if road.color != playerColor {
+		return WrongPieceColorErr
+	}
+
 	_, err := game.Player(playerColor)
 	if err != nil {
 		return err
@@ -91,7 +95,7 @@ func (gameStatePlayerIsToPlaceRoad *GameStatePlayerIsPlacingRoad) canBuildRoad(r
 			continue
 		}
 
-		if !adjacentPath.IsEmpty() {
+		if adjacentPath.IsEmpty() || adjacentPath.Road().color != road.color {
 			continue
 		}
 
@@ -101,7 +105,7 @@ func (gameStatePlayerIsToPlaceRoad *GameStatePlayerIsPlacingRoad) canBuildRoad(r
 		}
 
 		intersection, exists := game.Board().Intersection(jointIntersectionCoord)
-		if exists {
+		if !exists {
 			continue
 		}
 
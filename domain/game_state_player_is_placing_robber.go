@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/rannoch/catan/grid"
+import (
+	"time"
+
+	"github.com/rannoch/catan/grid"
+)
 
 type GameStatePlayerIsPlacingRobber struct {
 	game *Game
@@ -8,10 +12,84 @@ type GameStatePlayerIsPlacingRobber struct {
 	GameStateDefault
 }
 
-func (g *GameStatePlayerIsPlacingRobber) PlaceRobber(Color, grid.HexCoord) error {
-	return CommandIsForbiddenErr
+func NewGameStatePlayerIsPlacingRobber(game *Game) *GameStatePlayerIsPlacingRobber {
+	return &GameStatePlayerIsPlacingRobber{game: game}
 }
 
-func (g *GameStatePlayerIsPlacingRobber) Apply(eventMessage EventMessage, isNew bool) {
-	panic("implement me")
+func (g *GameStatePlayerIsPlacingRobber) PlaceRobber(playerColor Color, hexCoord grid.HexCoord, occurred time.Time) error {
+	game := g.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if err := g.canPlaceRobber(playerColor, hexCoord); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerMovedRobberEvent{
+				PlayerColor: playerColor,
+				HexCoord:    hexCoord,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	// nobody to choose from, the only player is robbed right away
+	if playersToRob := playersToRob(game, playerColor); len(playersToRob) == 1 {
+		return game.RobPlayer(playerColor, playersToRob[0], occurred)
+	}
+
+	return nil
+}
+
+func (g *GameStatePlayerIsPlacingRobber) canPlaceRobber(playerColor Color, hexCoord grid.HexCoord) error {
+	game := g.game
+	board := game.Board()
+
+	hex, exists := board.Hex(hexCoord)
+	if !exists || !hex.IsLand() {
+		return BadHexCoordErr
+	}
+
+	if robber, exists := board.Robber(); exists && robber == hexCoord {
+		return RobberMustBeMovedErr
+	}
+
+	if friendlyRobberVictoryPoints := game.Rules().FriendlyRobberVictoryPoints; friendlyRobberVictoryPoints > 0 {
+		for _, color := range colorsNextToHex(board, hexCoord) {
+			if color == playerColor {
+				continue
+			}
+
+			player, err := game.Player(color)
+			if err != nil {
+				return err
+			}
+
+			if player.VictoryPoints() <= friendlyRobberVictoryPoints {
+				return FriendlyRobberErr
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *GameStatePlayerIsPlacingRobber) Apply(eventMessage EventMessage, _ bool) {
+	game := g.game
+
+	switch event := eventMessage.Event().(type) {
+	case PlayerMovedRobberEvent:
+		err := game.Board().MoveRobber(event.HexCoord)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
@@ -23,7 +23,11 @@ func (gameStatePlayerIsToPlaceSettlement *GameStatePlayerIsPlacingSettlement) Pl
 		return WrongTurnErr
 	}
 
-	if err := gameStatePlayerIsToPlaceSettlement.canBuildSettlement(settlement); err != nil {
+	if settlement.Color() != playerColor {
+		return WrongPieceColorErr
+	}
+
+	if err := canBuildSettlement(game.Board(), settlement); err != nil {
 		return err
 	}
 
@@ -43,34 +47,6 @@ func (gameStatePlayerIsToPlaceSettlement *GameStatePlayerIsPlacingSettlement) Pl
 	return nil
 }
 
-func (gameStatePlayerIsToPlaceSettlement *GameStatePlayerIsPlacingSettlement) canBuildSettlement(settlement Settlement) error {
-	game := gameStatePlayerIsToPlaceSettlement.game
-
-	intersection, exists := game.Board().Intersection(settlement.IntersectionCoord())
-	if !exists {
-		return BadIntersectionCoordErr
-	}
-
-	if !intersection.IsEmpty() {
-		return IntersectionAlreadyHasObjectErr
-	}
-
-	// distance check
-	adjacentIntersectionsCoords := game.Board().IntersectionAdjacentIntersections(settlement.intersectionCoord)
-	for _, adjacentIntersectionCoord := range adjacentIntersectionsCoords {
-		adjacentIntersection, exists := game.Board().Intersection(adjacentIntersectionCoord)
-		if !exists {
-			continue
-		}
-
-		if !adjacentIntersection.IsEmpty() {
-			return CommandIsForbiddenErr
-		}
-	}
-
-	return nil
-}
-
 func (gameStatePlayerIsToPlaceSettlement *GameStatePlayerIsPlacingSettlement) Apply(eventMessage EventMessage, _ bool) {
 	game := gameStatePlayerIsToPlaceSettlement.game
 
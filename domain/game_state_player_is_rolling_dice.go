@@ -15,11 +15,92 @@ func NewGameStatePlayerIsRollingDice(game *Game) *gameStatePlayerIsRollingDice {
 }
 
 func (g *gameStatePlayerIsRollingDice) RollDice(playerColor Color, occurred time.Time) error {
-	// todo
-	//
+	game := g.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if game.DiceRoller() == nil {
+		return DiceRollerIsNotSelectedErr
+	}
+
+	roll := game.DiceRoller().Roll()
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerRolledDiceEvent{
+				Roll: roll,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	if roll.IsRobber() {
+		return nil
+	}
+
+	resourcesByColor := game.limitByBank(g.producedResources(roll.NumberToken()))
+
+	// players pick resources in the turn order
+	for _, color := range game.TurnOrder() {
+		resources := resourcesByColor[color]
+		if len(resources) == 0 {
+			continue
+		}
+
+		game.Apply(
+			NewEventDescriptor(
+				game.Id(),
+				PlayerPickedResourcesEvent{
+					PlayerColor:     color,
+					PickedResources: resources,
+				},
+				nil,
+				game.Version(),
+				occurred,
+			),
+			true,
+		)
+	}
+
 	return nil
 }
 
-func (g *gameStatePlayerIsRollingDice) Apply(eventMessage EventMessage, isNew bool) {
-	panic("implement me")
+// producedResources collects resources from every hex with the number token for every building around it
+func (g *gameStatePlayerIsRollingDice) producedResources(numberToken NumberToken) map[Color][]ResourceCard {
+	board := g.game.Board()
+
+	resourcesByColor := make(map[Color][]ResourceCard)
+
+	for _, hex := range board.HexesByNumberToken(int64(numberToken)) {
+		for _, intersectionCoord := range board.HexAdjacentIntersections(hex.Coord) {
+			intersection, exists := board.Intersection(intersectionCoord)
+			if !exists || intersection.IsEmpty() {
+				continue
+			}
+
+			building := intersection.Building()
+
+			resourcesByColor[building.Color()] = append(
+				resourcesByColor[building.Color()],
+				hex.Resource.GetResourceCard(building.ResourceCount())...,
+			)
+		}
+	}
+
+	return resourcesByColor
+}
+
+func (g *gameStatePlayerIsRollingDice) Apply(eventMessage EventMessage, _ bool) {
+	game := g.game
+
+	switch event := eventMessage.Event().(type) {
+	case PlayerRolledDiceEvent:
+		game.rollHistory = append(game.rollHistory, event.Roll)
+	}
 }
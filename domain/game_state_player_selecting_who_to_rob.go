@@ -1,15 +1,146 @@
 package domain
 
+import (
+	"errors"
+	"time"
+
+	"github.com/rannoch/catan/grid"
+)
+
+// PlayerCannotBeRobbedErr is used when the player has no buildings next to the robber or has no resources
+var PlayerCannotBeRobbedErr = errors.New("player cannot be robbed")
+
 type GameStatePlayerSelectingWhoToRob struct {
 	game *Game
 
 	GameStateDefault
 }
 
-func (GameStatePlayerSelectingWhoToRob) RobPlayer(playerColor Color, targetColor Color) error {
-	panic("implement me")
+func NewGameStatePlayerSelectingWhoToRob(game *Game) *GameStatePlayerSelectingWhoToRob {
+	return &GameStatePlayerSelectingWhoToRob{game: game}
 }
 
-func (GameStatePlayerSelectingWhoToRob) Apply(eventMessage EventMessage, isNew bool) {
-	panic("implement me")
+func (g *GameStatePlayerSelectingWhoToRob) RobPlayer(playerColor Color, targetColor Color, occurred time.Time) error {
+	game := g.game
+
+	if game.CurrentTurn() != playerColor {
+		return WrongTurnErr
+	}
+
+	if !g.canRob(playerColor, targetColor) {
+		return PlayerCannotBeRobbedErr
+	}
+
+	targetPlayer, err := game.Player(targetColor)
+	if err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerWasRobbedByPlayerEvent{
+				RobbingPlayerColor: playerColor,
+				RobbedPlayerColor:  targetColor,
+				DumpedResources:    []ResourceCard{game.ResourcePicker().Pick(targetPlayer.Resources())},
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+func (g *GameStatePlayerSelectingWhoToRob) canRob(playerColor Color, targetColor Color) bool {
+	for _, color := range playersToRob(g.game, playerColor) {
+		if color == targetColor {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *GameStatePlayerSelectingWhoToRob) Apply(eventMessage EventMessage, _ bool) {
+	game := g.game
+
+	switch event := eventMessage.Event().(type) {
+	case PlayerWasRobbedByPlayerEvent:
+		robbedPlayer, err := game.Player(event.RobbedPlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		err = game.updatePlayer(robbedPlayer.WithDisposedResources(event.DumpedResources))
+		if err != nil {
+			panic(err)
+		}
+
+		robbingPlayer, err := game.Player(event.RobbingPlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		robbingPlayer.GainResources(event.DumpedResources)
+
+		err = game.updatePlayer(robbingPlayer)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// playersToRob returns opponents in the turn order which have resources and buildings next to the robber
+func playersToRob(game *Game, robbingPlayerColor Color) []Color {
+	board := game.Board()
+
+	robber, exists := board.Robber()
+	if !exists {
+		return nil
+	}
+
+	nextToRobber := make(map[Color]bool)
+
+	for _, color := range colorsNextToHex(board, robber) {
+		nextToRobber[color] = true
+	}
+
+	var colors []Color
+
+	for _, color := range game.TurnOrder() {
+		if color == robbingPlayerColor || !nextToRobber[color] {
+			continue
+		}
+
+		player, err := game.Player(color)
+		if err != nil || len(player.Resources()) == 0 {
+			continue
+		}
+
+		colors = append(colors, color)
+	}
+
+	return colors
+}
+
+// colorsNextToHex returns colors of buildings around the hex, the color is repeated for every building
+func colorsNextToHex(board Board, hexCoord grid.HexCoord) []Color {
+	var colors []Color
+
+	for _, intersection := range board.Intersections() {
+		if intersection.IsEmpty() {
+			continue
+		}
+
+		for _, adjacentHexCoord := range board.IntersectionAdjacentHexes(intersection.coord) {
+			if adjacentHexCoord == hexCoord {
+				colors = append(colors, intersection.Building().Color())
+			}
+		}
+	}
+
+	return colors
 }
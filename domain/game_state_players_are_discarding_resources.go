@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultHandLimit players holding more resources discard half of them when 7 is rolled
+const DefaultHandLimit = 7
+
+// WrongNumberOfResourcesToDiscardErr is used when player discards not exactly half of his resources
+var WrongNumberOfResourcesToDiscardErr = errors.New("wrong number of resources to discard")
+
+// GameStatePlayersAreDiscardingResources every player over the hand limit discards half of his resources,
+// players discard simultaneously, the game waits until all of them are done
+type GameStatePlayersAreDiscardingResources struct {
+	game *Game
+
+	resourcesToDiscard map[Color]int64
+
+	GameStateDefault
+}
+
+func NewGameStatePlayersAreDiscardingResources(game *Game) *GameStatePlayersAreDiscardingResources {
+	return &GameStatePlayersAreDiscardingResources{game: game}
+}
+
+func (g *GameStatePlayersAreDiscardingResources) DiscardResources(playerColor Color, resources []ResourceCard, occurred time.Time) error {
+	game := g.game
+
+	player, err := game.Player(playerColor)
+	if err != nil {
+		return err
+	}
+
+	resourcesToDiscard, mustDiscard := g.resourcesToDiscard[playerColor]
+	if !mustDiscard {
+		return CommandIsForbiddenErr
+	}
+
+	if int64(len(resources)) != resourcesToDiscard {
+		return WrongNumberOfResourcesToDiscardErr
+	}
+
+	if err := player.HasResources(resources); err != nil {
+		return err
+	}
+
+	game.Apply(
+		NewEventDescriptor(
+			game.Id(),
+			PlayerWasRobbedByRobberEvent{
+				RobbedPlayerColor: playerColor,
+				DumpedResources:   resources,
+			},
+			nil,
+			game.Version(),
+			occurred,
+		),
+		true,
+	)
+
+	return nil
+}
+
+// start finds players over the hand limit
+func (g *GameStatePlayersAreDiscardingResources) start() {
+	game := g.game
+
+	g.resourcesToDiscard = make(map[Color]int64)
+
+	for _, player := range game.Players() {
+		resourcesCount := int64(len(player.Resources()))
+
+		if resourcesCount > game.HandLimit() {
+			g.resourcesToDiscard[player.Color()] = resourcesCount / 2
+		}
+	}
+}
+
+func (g *GameStatePlayersAreDiscardingResources) isFinished() bool {
+	return len(g.resourcesToDiscard) == 0
+}
+
+func (g *GameStatePlayersAreDiscardingResources) Apply(eventMessage EventMessage, _ bool) {
+	game := g.game
+
+	switch event := eventMessage.Event().(type) {
+	case PlayerWasRobbedByRobberEvent:
+		player, err := game.Player(event.RobbedPlayerColor)
+		if err != nil {
+			panic(err)
+		}
+
+		err = game.updatePlayer(player.WithDisposedResources(event.DumpedResources))
+		if err != nil {
+			panic(err)
+		}
+
+		game.returnToBank(event.DumpedResources)
+
+		delete(g.resourcesToDiscard, event.RobbedPlayerColor)
+	}
+}
@@ -24,6 +24,9 @@ func (gameStateStarted *GameStateStarted) EnterState(occurred time.Time) {
 	if err := game.ShufflePlayers(occurred); err != nil {
 		panic(err)
 	}
+	if err := game.ShuffleDevelopmentCards(occurred); err != nil {
+		panic(err)
+	}
 
 	initialSetupPhaseStartedEventMessage := NewEventDescriptor(
 		game.Id(),
@@ -45,6 +48,8 @@ func (gameStateStarted GameStateStarted) Apply(eventMessage EventMessage, _ bool
 		game.setBoard(event.NewBoard)
 	case PlayersShuffledEvent:
 		game.setTurnOrder(event.PlayersInOrder)
+	case DevelopmentCardsShuffledEvent:
+		game.setDevelopmentCardsDeck(event.DevelopmentCards)
 	case InitialSetupPhaseStartedEvent:
 		game.setState(game.stateInitialSetup)
 	}
@@ -89,3 +94,21 @@ func (gameStateStarted GameStateStarted) ShufflePlayers(occurred time.Time) erro
 
 	return nil
 }
+
+func (gameStateStarted GameStateStarted) ShuffleDevelopmentCards(occurred time.Time) error {
+	game := gameStateStarted.game
+
+	developmentCardsShuffledEventMessage := NewEventDescriptor(
+		game.Id(),
+		DevelopmentCardsShuffledEvent{
+			DevelopmentCards: game.DevelopmentCardsShuffler().Shuffle(NewDevelopmentCardsDeck()),
+		},
+		nil,
+		game.Version(),
+		occurred,
+	)
+
+	game.Apply(developmentCardsShuffledEventMessage, true)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "github.com/rannoch/catan/grid"
+
+const (
+	// LongestRoadMinLength is the least length of the road to get longest road
+	LongestRoadMinLength = 5
+	// LongestRoadVictoryPoints is given to the longest road owner
+	LongestRoadVictoryPoints = 2
+)
+
+// LongestRoad finds the longest continuous road of the player on the board,
+// a road can't go on through an intersection with an opponent's building
+func LongestRoad(board Board, color Color) int64 {
+	var longestRoad int64
+
+	for _, path := range board.Paths() {
+		road := path.Road()
+		if road == nil || road.color != color {
+			continue
+		}
+
+		visitedPaths := map[grid.PathCoord]bool{road.PathCoord(): true}
+
+		// the road is the end of the longest road, try to go on from both of its intersections
+		for _, intersectionCoord := range board.PathAdjacentIntersections(road.PathCoord()) {
+			length := 1 + longestRoadFrom(board, color, intersectionCoord, visitedPaths)
+
+			if length > longestRoad {
+				longestRoad = length
+			}
+		}
+	}
+
+	return longestRoad
+}
+
+// longestRoadFrom finds the longest road going on from the intersection without visited paths
+func longestRoadFrom(board Board, color Color, intersectionCoord grid.IntersectionCoord, visitedPaths map[grid.PathCoord]bool) int64 {
+	intersection, exists := board.Intersection(intersectionCoord)
+	if exists && !intersection.IsEmpty() && intersection.Building().Color() != color {
+		return 0
+	}
+
+	var longestRoad int64
+
+	for _, pathCoord := range board.IntersectionAdjacentPaths(intersectionCoord) {
+		if visitedPaths[pathCoord] {
+			continue
+		}
+
+		path, exists := board.Path(pathCoord)
+		if !exists || path.IsEmpty() || path.Road().color != color {
+			continue
+		}
+
+		visitedPaths[pathCoord] = true
+
+		for _, nextIntersectionCoord := range board.PathAdjacentIntersections(pathCoord) {
+			if nextIntersectionCoord == intersectionCoord {
+				continue
+			}
+
+			length := 1 + longestRoadFrom(board, color, nextIntersectionCoord, visitedPaths)
+
+			if length > longestRoad {
+				longestRoad = length
+			}
+		}
+
+		delete(visitedPaths, pathCoord)
+	}
+
+	return longestRoad
+}
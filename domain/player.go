@@ -15,7 +15,7 @@ const (
 	Yellow Color = "yellow"
 )
 
-var allColors = []Color{Red, Blue, White, Green, Yellow}
+var allColors = []Color{Red, Blue, White, Green, Yellow, Orange, Black}
 
 type Player struct {
 	userId UserId // User aggregate id, extract name and other info using this reference
@@ -34,20 +34,21 @@ type Player struct {
 	longestRoad      int64
 	longestRoadOwner bool
 	largestArmyOwner bool
+	playedKnights    int64
 
 	devCardPlayed bool
-	devCards      []string // todo
+	devCards      []DevelopmentCard
+	// cards bought during the current turn, they become playable on the next turn
+	newDevCards []DevelopmentCard
 }
 
+// NewPlayer creates the player without pieces, the game rules give them when the game is started
 func NewPlayer(color Color, userId UserId) Player {
 	player := Player{
 		color:  color,
 		userId: userId,
 
-		availableRoads:       15,
-		availableCities:      4,
-		availableSettlements: 5,
-		resourcesTypeCount:   make(map[ResourceCard]int64),
+		resourcesTypeCount: make(map[ResourceCard]int64),
 	}
 
 	return player
@@ -61,10 +62,43 @@ func (player Player) DevCardPlayed() bool {
 	return player.devCardPlayed
 }
 
+// DevelopmentCards returns all not played cards including just bought ones
+func (player Player) DevelopmentCards() []DevelopmentCard {
+	devCards := make([]DevelopmentCard, 0, len(player.devCards)+len(player.newDevCards))
+	devCards = append(devCards, player.devCards...)
+	devCards = append(devCards, player.newDevCards...)
+
+	return devCards
+}
+
+func (player Player) CanPlayDevelopmentCard(card DevelopmentCard) error {
+	if player.devCardPlayed {
+		return DevelopmentCardAlreadyPlayedErr
+	}
+
+	for _, devCard := range player.devCards {
+		if devCard == card {
+			return nil
+		}
+	}
+
+	for _, devCard := range player.newDevCards {
+		if devCard == card {
+			return DevelopmentCardIsNewErr
+		}
+	}
+
+	return DevelopmentCardIsNotOwnedErr
+}
+
 func (player Player) LargestArmyOwner() bool {
 	return player.largestArmyOwner
 }
 
+func (player Player) PlayedKnights() int64 {
+	return player.playedKnights
+}
+
 func (player Player) LongestRoadOwner() bool {
 	return player.longestRoadOwner
 }
@@ -77,6 +111,24 @@ func (player Player) VictoryPoints() int64 {
 	return player.victoryPoints
 }
 
+// HiddenVictoryPoints returns victory points of not revealed development cards
+func (player Player) HiddenVictoryPoints() int64 {
+	var hiddenVictoryPoints int64
+
+	for _, devCard := range player.DevelopmentCards() {
+		if devCard.Type() == VictoryPoint {
+			hiddenVictoryPoints++
+		}
+	}
+
+	return hiddenVictoryPoints
+}
+
+// TotalVictoryPoints returns victory points including hidden ones
+func (player Player) TotalVictoryPoints() int64 {
+	return player.victoryPoints + player.HiddenVictoryPoints()
+}
+
 func (player Player) AvailableRoads() int64 {
 	return player.availableRoads
 }
@@ -101,6 +153,10 @@ func (player Player) Resources() []ResourceCard {
 	return player.resources
 }
 
+func (player Player) ResourceCount(resource Resource) int64 {
+	return player.resourcesTypeCount[ResourceCard{resource: resource}]
+}
+
 func (player *Player) GainResources(resources []ResourceCard) {
 	for _, resource := range resources {
 		player.resourcesTypeCount[resource]++
@@ -109,19 +165,39 @@ func (player *Player) GainResources(resources []ResourceCard) {
 	player.resources = append(player.resources, resources...)
 }
 
+// WithDisposedResources returns the player without the resources, resources the player doesn't have are ignored
 func (player Player) WithDisposedResources(resources []ResourceCard) Player {
+	remainingResources := make([]ResourceCard, len(player.resources))
+	copy(remainingResources, player.resources)
+
+	resourcesTypeCount := player.copyResourcesTypeCount()
+
 	for _, resource := range resources {
-		player.resourcesTypeCount[resource]-- // todo possible below zero case
+		for i, remainingResource := range remainingResources {
+			if remainingResource != resource {
+				continue
+			}
+
+			remainingResources = append(remainingResources[:i], remainingResources[i+1:]...)
+			resourcesTypeCount[resource]--
+			break
+		}
 	}
 
-	player.resources = []ResourceCard{}
+	player.resources = remainingResources
+	player.resourcesTypeCount = resourcesTypeCount
+
+	return player
+}
+
+func (player Player) copyResourcesTypeCount() map[ResourceCard]int64 {
+	resourcesTypeCount := make(map[ResourceCard]int64, len(player.resourcesTypeCount))
+
 	for resource, count := range player.resourcesTypeCount {
-		for i := 0; i < int(count); i++ {
-			player.resources = append(player.resources, resource)
-		}
+		resourcesTypeCount[resource] = count
 	}
 
-	return player
+	return resourcesTypeCount
 }
 
 var (
@@ -155,10 +231,10 @@ func (player Player) HasAvailableRoad() error {
 	return nil
 }
 
-func (player Player) CanBuy(buyable Buyable) error {
-	resourcesTypeCount := player.resourcesTypeCount
+func (player Player) HasResources(resources []ResourceCard) error {
+	resourcesTypeCount := player.copyResourcesTypeCount()
 
-	for _, resource := range buyable.Cost() {
+	for _, resource := range resources {
 		if resourcesTypeCount[resource] == 0 {
 			return NotEnoughResourcesErr
 		}
@@ -169,14 +245,51 @@ func (player Player) CanBuy(buyable Buyable) error {
 	return nil
 }
 
+func (player Player) CanBuy(buyable Buyable) error {
+	return player.HasResources(buyable.Cost())
+}
+
 func (player Player) Buy(buyable Buyable) Player {
 	return player.WithDisposedResources(buyable.Cost())
 }
 
-func (player Player) HasPlacedInitialBuildings() bool {
-	return player.availableSettlements == 3
+func (player *Player) addDevelopmentCard(card DevelopmentCard) {
+	player.newDevCards = append(player.newDevCards, card)
+}
+
+func (player *Player) playDevelopmentCard(card DevelopmentCard) {
+	for i, devCard := range player.devCards {
+		if devCard == card {
+			player.devCards = append(player.devCards[:i:i], player.devCards[i+1:]...)
+			break
+		}
+	}
+
+	player.devCardPlayed = true
+
+	if card.Type() == Knight {
+		player.playedKnights++
+	}
+}
+
+// makeNewDevelopmentCardsPlayable is called at the end of the turn
+func (player *Player) makeNewDevelopmentCardsPlayable() {
+	player.devCards = append(player.devCards, player.newDevCards...)
+	player.newDevCards = nil
+	player.devCardPlayed = false
+}
+
+// setPieces gives the player all the pieces of the rules
+func (player *Player) setPieces(rules GameRules) {
+	player.availableSettlements = rules.Settlements
+	player.availableCities = rules.Cities
+	player.availableRoads = rules.Roads
+}
+
+func (player Player) HasPlacedInitialBuildings(rules GameRules) bool {
+	return rules.Settlements-player.availableSettlements == InitialSettlements
 }
 
-func (player Player) HasPlacedInitialBuildingsAndRoads() bool {
-	return player.availableSettlements == 3 && player.availableRoads == 13
+func (player Player) HasPlacedInitialBuildingsAndRoads(rules GameRules) bool {
+	return player.HasPlacedInitialBuildings(rules) && rules.Roads-player.availableRoads == InitialRoads
 }
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/rannoch/catan/grid"
+)
+
+const (
+	// DefaultTradeRate is the rate of the bank trade without ports
+	DefaultTradeRate = 4
+	// GenericPortTradeRate is the rate of 3:1 ports
+	GenericPortTradeRate = 3
+	// ResourcePortTradeRate is the rate of 2:1 ports for the port resource
+	ResourcePortTradeRate = 2
+)
+
+var (
+	// PortMustBeOnCoastErr is used when port is added to the path not on the coast
+	PortMustBeOnCoastErr = errors.New("port must be on the coast")
+	// BadTradeErr is used when the player gives and takes the same resource
+	BadTradeErr = errors.New("resource cannot be traded for the same resource")
+)
+
+// Port
+// is placed on the coastal path, players with buildings on its intersections trade with the bank at a better rate
+type Port struct {
+	resource Resource
+}
+
+// NewGenericPort creates 3:1 port for any resource
+func NewGenericPort() Port {
+	return Port{resource: EmptyResource}
+}
+
+// NewResourcePort creates 2:1 port for the resource
+func NewResourcePort(resource Resource) Port {
+	return Port{resource: resource}
+}
+
+func (port Port) Resource() Resource {
+	return port.resource
+}
+
+func (port Port) IsGeneric() bool {
+	return port.resource == EmptyResource
+}
+
+// TradeRate returns how many cards of the resource are given for one card
+func (port Port) TradeRate(resource ResourceCard) int64 {
+	if port.IsGeneric() {
+		return GenericPortTradeRate
+	}
+
+	if port.resource == resource.resource {
+		return ResourcePortTradeRate
+	}
+
+	return DefaultTradeRate
+}
+
+// MaritimeTradeRate returns the best rate the player has for the resource from ports with his buildings
+func MaritimeTradeRate(board Board, color Color, resource ResourceCard) int64 {
+	tradeRate := int64(DefaultTradeRate)
+
+	for _, intersection := range board.Intersections() {
+		if intersection.IsEmpty() || intersection.Building().Color() != color {
+			continue
+		}
+
+		port, exists := intersection.Port()
+		if !exists {
+			continue
+		}
+
+		if portTradeRate := port.TradeRate(resource); portTradeRate < tradeRate {
+			tradeRate = portTradeRate
+		}
+	}
+
+	return tradeRate
+}
+
+// isCoastalIntersection returns true when the intersection is next to water or the edge of the board
+func isCoastalIntersection(board Board, intersectionCoord grid.IntersectionCoord) bool {
+	for _, hexCoord := range board.IntersectionAdjacentHexes(intersectionCoord) {
+		hex, exists := board.Hex(hexCoord)
+		if !exists || !hex.IsLand() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
+
+// Seeded
+// is implemented by randomizers reproducible from the seed, the seed is recorded when they are selected,
+// crypto random ones return false
+type Seeded interface {
+	Seed() (int64, bool)
+}
+
+// seedOf returns the seed recorded in the selection event, nil when the randomizer can't be reproduced
+func seedOf(randomizer interface{}) *int64 {
+	seeded, ok := randomizer.(Seeded)
+	if !ok {
+		return nil
+	}
+
+	seed, ok := seeded.Seed()
+	if !ok {
+		return nil
+	}
+
+	return &seed
+}
+
+func newRand(seed int64, crypto bool) *rand.Rand {
+	if crypto {
+		return rand.New(cryptoSource{})
+	}
+
+	return rand.New(rand.NewSource(seed))
+}
+
+// cryptoSource reads crypto/rand for games where the next roll must not be predictable
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	var b [8]byte
+
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	return int64(binary.BigEndian.Uint64(b[:]) &^ (1 << 63))
+}
+
+func (cryptoSource) Seed(int64) {}
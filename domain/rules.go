@@ -0,0 +1,121 @@
+package domain
+
+import "errors"
+
+const (
+	// DefaultVictoryPointsToWin is the target score of the base game
+	DefaultVictoryPointsToWin = 10
+
+	DefaultSettlements = 5
+	DefaultCities      = 4
+	DefaultRoads       = 15
+
+	DefaultMinPlayers = 3
+	DefaultMaxPlayers = 4
+
+	// the 5-6 player extension
+	ExtensionMinPlayers           = 5
+	ExtensionMaxPlayers           = 6
+	ExtensionBankResourcesPerType = 24
+
+	// InitialSettlements every player places during the initial setup, one road is placed with each of them
+	InitialSettlements = 2
+	InitialRoads       = 2
+)
+
+var (
+	// BadGameRulesErr is used when rules can't be played with
+	BadGameRulesErr = errors.New("bad game rules")
+	// BadHandLimitErr is used when the hand limit is not positive
+	BadHandLimitErr = errors.New("hand limit must be positive")
+	// GameIsFullErr is used when the player joins the game with the max number of players
+	GameIsFullErr = errors.New("game is full")
+	// NotEnoughPlayersErr is used when the game is started with less than min number of players
+	NotEnoughPlayersErr = errors.New("not enough players")
+	// FriendlyRobberErr is used when robber is placed next to the player with few victory points
+	FriendlyRobberErr = errors.New("robber cannot be placed next to the player with few victory points")
+	// TradeIsNotAllowedErr is used when the trade is disabled by the rules
+	TradeIsNotAllowedErr = errors.New("trade is not allowed by the rules")
+)
+
+// GameRules
+// are selected before the game is started, every state consults them instead of hard-coded numbers
+type GameRules struct {
+	VictoryPointsToWin int64
+
+	// players holding more resources than the hand limit discard half of them when 7 is rolled
+	HandLimit int64
+
+	// pieces every player has
+	Settlements int64
+	Cities      int64
+	Roads       int64
+
+	MinPlayers int64
+	MaxPlayers int64
+
+	// robber cannot be placed next to opponents with this number of public victory points or less, 0 turns the rule off
+	FriendlyRobberVictoryPoints int64
+
+	DomesticTradeAllowed bool
+	MaritimeTradeAllowed bool
+
+	BankResourcesPerType int64
+
+	// every other player may build but not trade after the current player ends his turn
+	SpecialBuildingPhase bool
+}
+
+func DefaultGameRules() GameRules {
+	return GameRules{
+		VictoryPointsToWin: DefaultVictoryPointsToWin,
+		HandLimit:          DefaultHandLimit,
+
+		Settlements: DefaultSettlements,
+		Cities:      DefaultCities,
+		Roads:       DefaultRoads,
+
+		MinPlayers: DefaultMinPlayers,
+		MaxPlayers: DefaultMaxPlayers,
+
+		DomesticTradeAllowed: true,
+		MaritimeTradeAllowed: true,
+
+		BankResourcesPerType: BankResourcesPerType,
+	}
+}
+
+// ExtensionGameRules are rules of the 5-6 player extension
+func ExtensionGameRules() GameRules {
+	rules := DefaultGameRules()
+	rules.MinPlayers = ExtensionMinPlayers
+	rules.MaxPlayers = ExtensionMaxPlayers
+	rules.BankResourcesPerType = ExtensionBankResourcesPerType
+	rules.SpecialBuildingPhase = true
+
+	return rules
+}
+
+func (rules GameRules) Validate() error {
+	if rules.HandLimit <= 0 {
+		return BadHandLimitErr
+	}
+
+	if rules.VictoryPointsToWin <= 0 || rules.FriendlyRobberVictoryPoints < 0 {
+		return BadGameRulesErr
+	}
+
+	if rules.Settlements < InitialSettlements || rules.Roads < InitialRoads || rules.Cities < 0 {
+		return BadGameRulesErr
+	}
+
+	if rules.MinPlayers <= 0 || rules.MinPlayers > rules.MaxPlayers || rules.MaxPlayers > ExtensionMaxPlayers {
+		return BadGameRulesErr
+	}
+
+	if rules.BankResourcesPerType <= 0 {
+		return BadGameRulesErr
+	}
+
+	return nil
+}
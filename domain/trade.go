@@ -0,0 +1,124 @@
+package domain
+
+import "errors"
+
+var (
+	// TradeOfferNotFoundErr is used when the offer doesn't exist or is already closed
+	TradeOfferNotFoundErr = errors.New("trade offer not found")
+	// TradeOfferIsNotAcceptedErr is used when the trade is confirmed with the player who didn't accept the offer
+	TradeOfferIsNotAcceptedErr = errors.New("trade offer is not accepted by the player")
+)
+
+// TradeOffer
+// is proposed by the current player to opponents, it lives until the trade is confirmed, the hand doesn't cover it or the turn ends
+type TradeOffer struct {
+	id          int64
+	playerColor Color
+
+	givenResources []ResourceCard
+	takenResources []ResourceCard
+
+	// empty target colors mean the offer is for everybody
+	targetColors   []Color
+	acceptedColors []Color
+	rejectedColors []Color
+}
+
+func (tradeOffer TradeOffer) Id() int64 {
+	return tradeOffer.id
+}
+
+func (tradeOffer TradeOffer) PlayerColor() Color {
+	return tradeOffer.playerColor
+}
+
+// GivenResources returns resources the proposing player gives
+func (tradeOffer TradeOffer) GivenResources() []ResourceCard {
+	return tradeOffer.givenResources
+}
+
+// TakenResources returns resources the proposing player takes
+func (tradeOffer TradeOffer) TakenResources() []ResourceCard {
+	return tradeOffer.takenResources
+}
+
+func (tradeOffer TradeOffer) TargetColors() []Color {
+	return tradeOffer.targetColors
+}
+
+func (tradeOffer TradeOffer) AcceptedColors() []Color {
+	return tradeOffer.acceptedColors
+}
+
+func (tradeOffer TradeOffer) RejectedColors() []Color {
+	return tradeOffer.rejectedColors
+}
+
+func (tradeOffer TradeOffer) IsTargetedAt(color Color) bool {
+	if color == tradeOffer.playerColor {
+		return false
+	}
+
+	if len(tradeOffer.targetColors) == 0 {
+		return true
+	}
+
+	return containsColor(tradeOffer.targetColors, color)
+}
+
+func (tradeOffer TradeOffer) IsAcceptedBy(color Color) bool {
+	return containsColor(tradeOffer.acceptedColors, color)
+}
+
+// hasAnswered returns true when the player already accepted or rejected the offer
+func (tradeOffer TradeOffer) hasAnswered(color Color) bool {
+	return containsColor(tradeOffer.acceptedColors, color) || containsColor(tradeOffer.rejectedColors, color)
+}
+
+func (tradeOffer *TradeOffer) accept(color Color) {
+	tradeOffer.acceptedColors = append(tradeOffer.acceptedColors, color)
+}
+
+func (tradeOffer *TradeOffer) reject(color Color) {
+	tradeOffer.acceptedColors = removeColor(tradeOffer.acceptedColors, color)
+	tradeOffer.rejectedColors = append(tradeOffer.rejectedColors, color)
+}
+
+// isValidTrade checks both sides give something and no resource is traded for itself
+func isValidTrade(givenResources []ResourceCard, takenResources []ResourceCard) bool {
+	if len(givenResources) == 0 || len(takenResources) == 0 {
+		return false
+	}
+
+	for _, givenResource := range givenResources {
+		for _, takenResource := range takenResources {
+			if givenResource == takenResource {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func containsColor(colors []Color, color Color) bool {
+	for _, c := range colors {
+		if c == color {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeColor(colors []Color, color Color) []Color {
+	var remainingColors []Color
+
+	for _, c := range colors {
+		if c != color {
+			remainingColors = append(remainingColors, c)
+		}
+	}
+
+	return remainingColors
+}
@@ -0,0 +1,362 @@
+package grid
+
+// CubeCoord
+// is the point of the cube grid, Q + R + S is always 0.
+// Hexes are cube points themselves, intersections are sums of their three hexes and paths are sums of their two hexes,
+// so rotation and reflection of the sum is the sum of rotated or reflected hexes
+type CubeCoord struct {
+	Q int64
+	R int64
+	S int64
+}
+
+// CubeIntersectionCoord is the sum of three hexes around the intersection
+type CubeIntersectionCoord CubeCoord
+
+// CubePathCoord is the sum of two hexes on both sides of the path
+type CubePathCoord CubeCoord
+
+// cubeDirections are offsets of adjacent hexes going around the hex,
+// the same order as hexes (R-1,C-1), (R-1,C), (R,C+1), (R+1,C+1), (R+1,C), (R,C-1) of the offset grid
+var cubeDirections = []CubeCoord{
+	{Q: 0, R: -1, S: 1},
+	{Q: 1, R: -1, S: 0},
+	{Q: 1, R: 0, S: -1},
+	{Q: 0, R: 1, S: -1},
+	{Q: -1, R: 1, S: 0},
+	{Q: -1, R: 0, S: 1},
+}
+
+func (coord CubeCoord) Add(other CubeCoord) CubeCoord {
+	return CubeCoord{Q: coord.Q + other.Q, R: coord.R + other.R, S: coord.S + other.S}
+}
+
+func (coord CubeCoord) Subtract(other CubeCoord) CubeCoord {
+	return CubeCoord{Q: coord.Q - other.Q, R: coord.R - other.R, S: coord.S - other.S}
+}
+
+func (coord CubeCoord) Scale(k int64) CubeCoord {
+	return CubeCoord{Q: coord.Q * k, R: coord.R * k, S: coord.S * k}
+}
+
+// RotateRight rotates the point by 60 degrees clockwise around the origin
+func (coord CubeCoord) RotateRight() CubeCoord {
+	return CubeCoord{Q: -coord.R, R: -coord.S, S: -coord.Q}
+}
+
+// RotateLeft rotates the point by 60 degrees counterclockwise around the origin
+func (coord CubeCoord) RotateLeft() CubeCoord {
+	return CubeCoord{Q: -coord.S, R: -coord.Q, S: -coord.R}
+}
+
+// Reflect reflects the point across the Q axis
+func (coord CubeCoord) Reflect() CubeCoord {
+	return CubeCoord{Q: coord.Q, R: coord.S, S: coord.R}
+}
+
+// Distance returns the number of steps between two hexes
+func (coord CubeCoord) Distance(other CubeCoord) int64 {
+	diff := coord.Subtract(other)
+
+	return (abs(diff.Q) + abs(diff.R) + abs(diff.S)) / 2
+}
+
+func (coord CubeIntersectionCoord) RotateRight() CubeIntersectionCoord {
+	return CubeIntersectionCoord(CubeCoord(coord).RotateRight())
+}
+
+func (coord CubeIntersectionCoord) RotateLeft() CubeIntersectionCoord {
+	return CubeIntersectionCoord(CubeCoord(coord).RotateLeft())
+}
+
+func (coord CubeIntersectionCoord) Reflect() CubeIntersectionCoord {
+	return CubeIntersectionCoord(CubeCoord(coord).Reflect())
+}
+
+func (coord CubePathCoord) RotateRight() CubePathCoord {
+	return CubePathCoord(CubeCoord(coord).RotateRight())
+}
+
+func (coord CubePathCoord) RotateLeft() CubePathCoord {
+	return CubePathCoord(CubeCoord(coord).RotateLeft())
+}
+
+func (coord CubePathCoord) Reflect() CubePathCoord {
+	return CubePathCoord(CubeCoord(coord).Reflect())
+}
+
+func HexToCube(hexCoord HexCoord) CubeCoord {
+	return CubeCoord{Q: hexCoord.C - hexCoord.R, R: hexCoord.R, S: -hexCoord.C}
+}
+
+func CubeToHex(coord CubeCoord) (HexCoord, bool) {
+	if coord.Q+coord.R+coord.S != 0 {
+		return HexCoord{}, false
+	}
+
+	return HexCoord{R: coord.R, C: -coord.S}, true
+}
+
+func IntersectionToCube(intersectionCoord IntersectionCoord) CubeIntersectionCoord {
+	r, c := intersectionCoord.R, intersectionCoord.C
+
+	if intersectionCoord.D == L {
+		return CubeIntersectionCoord{Q: 3*c - 3*r - 1, R: 3*r - 1, S: -3*c + 2}
+	}
+
+	return CubeIntersectionCoord{Q: 3*c - 3*r + 1, R: 3*r + 1, S: -3*c - 2}
+}
+
+func CubeToIntersection(coord CubeIntersectionCoord) (IntersectionCoord, bool) {
+	if coord.Q+coord.R+coord.S != 0 {
+		return IntersectionCoord{}, false
+	}
+
+	var intersectionCoord IntersectionCoord
+
+	switch mod(coord.R, 3) {
+	case 1:
+		intersectionCoord = IntersectionCoord{R: (coord.R - 1) / 3, C: -(coord.S + 2) / 3, D: R}
+	case 2:
+		intersectionCoord = IntersectionCoord{R: (coord.R + 1) / 3, C: (2 - coord.S) / 3, D: L}
+	default:
+		return IntersectionCoord{}, false
+	}
+
+	// sums of hexes not around one intersection have no offset coord
+	if IntersectionToCube(intersectionCoord) != coord {
+		return IntersectionCoord{}, false
+	}
+
+	return intersectionCoord, true
+}
+
+func PathToCube(pathCoord PathCoord) CubePathCoord {
+	r, c := pathCoord.R, pathCoord.C
+
+	switch pathCoord.D {
+	case W:
+		return CubePathCoord{Q: 2*c - 2*r, R: 2*r - 1, S: -2*c + 1}
+	case N:
+		return CubePathCoord{Q: 2*c - 2*r + 1, R: 2*r - 1, S: -2 * c}
+	}
+
+	return CubePathCoord{Q: 2*c - 2*r + 1, R: 2 * r, S: -2*c - 1}
+}
+
+func CubeToPath(coord CubePathCoord) (PathCoord, bool) {
+	if coord.Q+coord.R+coord.S != 0 {
+		return PathCoord{}, false
+	}
+
+	var pathCoord PathCoord
+
+	switch {
+	case mod(coord.R, 2) == 1 && mod(coord.S, 2) == 1:
+		pathCoord = PathCoord{R: (coord.R + 1) / 2, C: (1 - coord.S) / 2, D: W}
+	case mod(coord.R, 2) == 1:
+		pathCoord = PathCoord{R: (coord.R + 1) / 2, C: -coord.S / 2, D: N}
+	default:
+		pathCoord = PathCoord{R: coord.R / 2, C: (-coord.S - 1) / 2, D: E}
+	}
+
+	// sums of hexes not adjacent to each other have no offset coord
+	if PathToCube(pathCoord) != coord {
+		return PathCoord{}, false
+	}
+
+	return pathCoord, true
+}
+
+// HexagonGridWithCubeCoordsCalculator
+// answers the same questions as HexagonGridWithOffsetCoordsCalculator with the cube grid math
+type HexagonGridWithCubeCoordsCalculator struct{}
+
+var _ HexagonGridCalculator = HexagonGridWithCubeCoordsCalculator{}
+
+func (h HexagonGridWithCubeCoordsCalculator) IntersectionAdjacentHexes(intersectionCoord IntersectionCoord) []HexCoord {
+	return cubesToHexes(intersectionHexes(IntersectionToCube(intersectionCoord)))
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) IntersectionAdjacentPaths(intersectionCoord IntersectionCoord) []PathCoord {
+	hexes := intersectionHexes(IntersectionToCube(intersectionCoord))
+
+	return cubesToPaths([]CubePathCoord{
+		CubePathCoord(hexes[0].Add(hexes[1])),
+		CubePathCoord(hexes[1].Add(hexes[2])),
+		CubePathCoord(hexes[2].Add(hexes[0])),
+	})
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) IntersectionAdjacentIntersections(intersectionCoord IntersectionCoord) []IntersectionCoord {
+	hexes := intersectionHexes(IntersectionToCube(intersectionCoord))
+
+	var intersections []CubeIntersectionCoord
+
+	// the neighbour shares two hexes, the third one is reflected across the path between them
+	for i := range hexes {
+		a, b, c := hexes[i], hexes[(i+1)%3], hexes[(i+2)%3]
+
+		intersections = append(intersections, CubeIntersectionCoord(a.Scale(2).Add(b.Scale(2)).Subtract(c)))
+	}
+
+	return cubesToIntersections(intersections)
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) HexAdjacentIntersections(hexCoord HexCoord) []IntersectionCoord {
+	hex := HexToCube(hexCoord)
+
+	var intersections []CubeIntersectionCoord
+
+	for i := range cubeDirections {
+		intersections = append(intersections, CubeIntersectionCoord(
+			hex.Scale(3).Add(cubeDirections[i]).Add(cubeDirections[(i+1)%len(cubeDirections)]),
+		))
+	}
+
+	return cubesToIntersections(intersections)
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) HexAdjacentPaths(hexCoord HexCoord) []PathCoord {
+	hex := HexToCube(hexCoord)
+
+	var paths []CubePathCoord
+
+	for _, direction := range cubeDirections {
+		paths = append(paths, CubePathCoord(hex.Scale(2).Add(direction)))
+	}
+
+	return cubesToPaths(paths)
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) PathAdjacentIntersections(pathCoord PathCoord) []IntersectionCoord {
+	a, b, thirdHexes := pathHexes(PathToCube(pathCoord))
+
+	var intersections []CubeIntersectionCoord
+
+	for _, c := range thirdHexes {
+		intersections = append(intersections, CubeIntersectionCoord(a.Add(b).Add(c)))
+	}
+
+	return cubesToIntersections(intersections)
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) PathAdjacentPaths(pathCoord PathCoord) []PathCoord {
+	a, b, thirdHexes := pathHexes(PathToCube(pathCoord))
+
+	var paths []CubePathCoord
+
+	for _, c := range thirdHexes {
+		paths = append(paths, CubePathCoord(a.Add(c)), CubePathCoord(b.Add(c)))
+	}
+
+	return cubesToPaths(paths)
+}
+
+func (h HexagonGridWithCubeCoordsCalculator) PathsJointIntersection(pathCoord1, pathCoord2 PathCoord) (IntersectionCoord, bool) {
+	for _, intersectionCoord1 := range h.PathAdjacentIntersections(pathCoord1) {
+		for _, intersectionCoord2 := range h.PathAdjacentIntersections(pathCoord2) {
+			if intersectionCoord1 == intersectionCoord2 {
+				return intersectionCoord1, true
+			}
+		}
+	}
+
+	return IntersectionCoord{}, false
+}
+
+// intersectionHexes returns three hexes the intersection is the sum of
+func intersectionHexes(coord CubeIntersectionCoord) []CubeCoord {
+	for i := range cubeDirections {
+		d1, d2 := cubeDirections[i], cubeDirections[(i+1)%len(cubeDirections)]
+
+		rest := CubeCoord(coord).Subtract(d1).Subtract(d2)
+		if mod(rest.Q, 3) != 0 || mod(rest.R, 3) != 0 || mod(rest.S, 3) != 0 {
+			continue
+		}
+
+		a := CubeCoord{Q: rest.Q / 3, R: rest.R / 3, S: rest.S / 3}
+
+		return []CubeCoord{a, a.Add(d1), a.Add(d2)}
+	}
+
+	return nil
+}
+
+// pathHexes returns two hexes the path is the sum of and two hexes next to both of them
+func pathHexes(coord CubePathCoord) (CubeCoord, CubeCoord, []CubeCoord) {
+	for i, direction := range cubeDirections {
+		rest := CubeCoord(coord).Subtract(direction)
+		if mod(rest.Q, 2) != 0 || mod(rest.R, 2) != 0 || mod(rest.S, 2) != 0 {
+			continue
+		}
+
+		a := CubeCoord{Q: rest.Q / 2, R: rest.R / 2, S: rest.S / 2}
+
+		return a, a.Add(direction), []CubeCoord{
+			a.Add(cubeDirections[(i+len(cubeDirections)-1)%len(cubeDirections)]),
+			a.Add(cubeDirections[(i+1)%len(cubeDirections)]),
+		}
+	}
+
+	return CubeCoord{}, CubeCoord{}, nil
+}
+
+// cubesToHexes skips coords with no offset coord, the same for intersections and paths
+func cubesToHexes(coords []CubeCoord) []HexCoord {
+	hexCoords := make([]HexCoord, 0, len(coords))
+
+	for _, coord := range coords {
+		hexCoord, ok := CubeToHex(coord)
+		if !ok {
+			continue
+		}
+
+		hexCoords = append(hexCoords, hexCoord)
+	}
+
+	return hexCoords
+}
+
+func cubesToIntersections(coords []CubeIntersectionCoord) []IntersectionCoord {
+	intersectionCoords := make([]IntersectionCoord, 0, len(coords))
+
+	for _, coord := range coords {
+		intersectionCoord, ok := CubeToIntersection(coord)
+		if !ok {
+			continue
+		}
+
+		intersectionCoords = append(intersectionCoords, intersectionCoord)
+	}
+
+	return intersectionCoords
+}
+
+func cubesToPaths(coords []CubePathCoord) []PathCoord {
+	pathCoords := make([]PathCoord, 0, len(coords))
+
+	for _, coord := range coords {
+		pathCoord, ok := CubeToPath(coord)
+		if !ok {
+			continue
+		}
+
+		pathCoords = append(pathCoords, pathCoord)
+	}
+
+	return pathCoords
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// mod is always positive unlike %
+func mod(n, m int64) int64 {
+	return (n%m + m) % m
+}
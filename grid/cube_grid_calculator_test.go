@@ -0,0 +1,145 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testHexCoords() []HexCoord {
+	var hexCoords []HexCoord
+
+	for r := int64(-4); r <= 4; r++ {
+		for c := int64(-4); c <= 4; c++ {
+			hexCoords = append(hexCoords, HexCoord{R: r, C: c})
+		}
+	}
+
+	return hexCoords
+}
+
+func TestCubeCoordConversions(t *testing.T) {
+	for _, hexCoord := range testHexCoords() {
+		got, ok := CubeToHex(HexToCube(hexCoord))
+		assert.True(t, ok)
+		assert.Equal(t, hexCoord, got)
+
+		for _, d := range []IntersectionDirection{L, R} {
+			intersectionCoord := IntersectionCoord{R: hexCoord.R, C: hexCoord.C, D: d}
+
+			got, ok := CubeToIntersection(IntersectionToCube(intersectionCoord))
+			assert.True(t, ok)
+			assert.Equal(t, intersectionCoord, got)
+		}
+
+		for _, d := range []PathDirection{W, N, E} {
+			pathCoord := PathCoord{R: hexCoord.R, C: hexCoord.C, D: d}
+
+			got, ok := CubeToPath(PathToCube(pathCoord))
+			assert.True(t, ok)
+			assert.Equal(t, pathCoord, got)
+		}
+	}
+}
+
+func TestCubeCoordConversions_BadCoords(t *testing.T) {
+	_, ok := CubeToHex(CubeCoord{Q: 1, R: 1, S: 1})
+	assert.False(t, ok)
+
+	// the hex itself is not the intersection or the path
+	_, ok = CubeToIntersection(CubeIntersectionCoord{Q: 3, R: -3, S: 0})
+	assert.False(t, ok)
+
+	_, ok = CubeToPath(CubePathCoord{Q: 2, R: -2, S: 0})
+	assert.False(t, ok)
+}
+
+func TestCubesToCoords_SkipBadCoords(t *testing.T) {
+	assert.Equal(t, []HexCoord{{R: 0, C: 0}}, cubesToHexes([]CubeCoord{{Q: 1, R: 1, S: 1}, HexToCube(HexCoord{R: 0, C: 0})}))
+
+	intersectionCoord := IntersectionCoord{R: 0, C: 0, D: L}
+	assert.Equal(t, []IntersectionCoord{intersectionCoord}, cubesToIntersections([]CubeIntersectionCoord{
+		{Q: 3, R: -3, S: 0},
+		IntersectionToCube(intersectionCoord),
+	}))
+
+	pathCoord := PathCoord{R: 0, C: 0, D: N}
+	assert.Equal(t, []PathCoord{pathCoord}, cubesToPaths([]CubePathCoord{
+		{Q: 2, R: -2, S: 0},
+		PathToCube(pathCoord),
+	}))
+}
+
+func TestHexagonGridWithCubeCoordsCalculator_MatchesOffsetCalculator(t *testing.T) {
+	offset := HexagonGridWithOffsetCoordsCalculator{}
+	cube := HexagonGridWithCubeCoordsCalculator{}
+
+	for _, hexCoord := range testHexCoords() {
+		assert.ElementsMatch(t, offset.HexAdjacentIntersections(hexCoord), cube.HexAdjacentIntersections(hexCoord))
+		assert.ElementsMatch(t, offset.HexAdjacentPaths(hexCoord), cube.HexAdjacentPaths(hexCoord))
+
+		for _, d := range []IntersectionDirection{L, R} {
+			intersectionCoord := IntersectionCoord{R: hexCoord.R, C: hexCoord.C, D: d}
+
+			assert.ElementsMatch(t, offset.IntersectionAdjacentHexes(intersectionCoord), cube.IntersectionAdjacentHexes(intersectionCoord))
+			assert.ElementsMatch(t, offset.IntersectionAdjacentPaths(intersectionCoord), cube.IntersectionAdjacentPaths(intersectionCoord))
+			assert.ElementsMatch(t, offset.IntersectionAdjacentIntersections(intersectionCoord), cube.IntersectionAdjacentIntersections(intersectionCoord))
+		}
+
+		for _, d := range []PathDirection{W, N, E} {
+			pathCoord := PathCoord{R: hexCoord.R, C: hexCoord.C, D: d}
+
+			assert.ElementsMatch(t, offset.PathAdjacentIntersections(pathCoord), cube.PathAdjacentIntersections(pathCoord))
+			assert.ElementsMatch(t, offset.PathAdjacentPaths(pathCoord), cube.PathAdjacentPaths(pathCoord))
+		}
+	}
+}
+
+func TestCubeCoord_Rotation(t *testing.T) {
+	calculator := HexagonGridWithCubeCoordsCalculator{}
+
+	for _, hexCoord := range testHexCoords() {
+		coord := HexToCube(hexCoord)
+
+		rotated := coord
+		for i := 0; i < 6; i++ {
+			rotated = rotated.RotateRight()
+		}
+		assert.Equal(t, coord, rotated)
+		assert.Equal(t, coord, coord.RotateRight().RotateLeft())
+		assert.Equal(t, coord, coord.Reflect().Reflect())
+		assert.Equal(t, coord.Distance(CubeCoord{}), coord.RotateRight().Distance(CubeCoord{}))
+
+		// rotated intersection is around rotated hexes
+		intersectionCoord := IntersectionCoord{R: hexCoord.R, C: hexCoord.C, D: R}
+		rotatedIntersectionCoord, ok := CubeToIntersection(IntersectionToCube(intersectionCoord).RotateRight())
+		assert.True(t, ok)
+
+		var rotatedHexCoords []HexCoord
+		for _, adjacentHexCoord := range calculator.IntersectionAdjacentHexes(intersectionCoord) {
+			rotatedHexCoord, _ := CubeToHex(HexToCube(adjacentHexCoord).RotateRight())
+			rotatedHexCoords = append(rotatedHexCoords, rotatedHexCoord)
+		}
+		assert.ElementsMatch(t, rotatedHexCoords, calculator.IntersectionAdjacentHexes(rotatedIntersectionCoord))
+	}
+}
+
+func TestCubeCoord_Distance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b HexCoord
+		want int64
+	}{
+		{name: "same hex", a: HexCoord{R: 2, C: 2}, b: HexCoord{R: 2, C: 2}, want: 0},
+		{name: "adjacent hexes", a: HexCoord{R: 2, C: 2}, b: HexCoord{R: 1, C: 1}, want: 1},
+		{name: "across the base board", a: HexCoord{R: 0, C: 0}, b: HexCoord{R: 4, C: 4}, want: 4},
+		{name: "corners of the same row", a: HexCoord{R: 2, C: 0}, b: HexCoord{R: 2, C: 4}, want: 4},
+		{name: "not along the axis", a: HexCoord{R: 0, C: 2}, b: HexCoord{R: 2, C: 0}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HexToCube(tt.a).Distance(HexToCube(tt.b)))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package grid
+
+// hexDirections are offsets of adjacent hexes going clockwise from the top left one
+var hexDirections = []HexCoord{
+	{R: -1, C: -1},
+	{R: -1, C: 0},
+	{R: 0, C: 1},
+	{R: 1, C: 1},
+	{R: 1, C: 0},
+	{R: 0, C: -1},
+}
+
+// HexDistance returns the number of steps between two hexes.
+// It works on offset coords every calculator takes, so it needs no calculator, the same for HexRing and HexSpiral
+func HexDistance(a, b HexCoord) int64 {
+	return HexToCube(a).Distance(HexToCube(b))
+}
+
+// HexNeighbours returns hexes sharing an intersection with the hex
+func HexNeighbours(calculator HexagonGridCalculator, hexCoord HexCoord) []HexCoord {
+	var neighbours []HexCoord
+	visited := map[HexCoord]bool{hexCoord: true}
+
+	for _, intersectionCoord := range calculator.HexAdjacentIntersections(hexCoord) {
+		for _, adjacentHexCoord := range calculator.IntersectionAdjacentHexes(intersectionCoord) {
+			if visited[adjacentHexCoord] {
+				continue
+			}
+
+			visited[adjacentHexCoord] = true
+			neighbours = append(neighbours, adjacentHexCoord)
+		}
+	}
+
+	return neighbours
+}
+
+// HexRing returns hexes at the distance from the center going clockwise from the top left corner
+func HexRing(center HexCoord, radius int64) []HexCoord {
+	if radius <= 0 {
+		return []HexCoord{center}
+	}
+
+	hexCoord := HexCoord{R: center.R + hexDirections[0].R*radius, C: center.C + hexDirections[0].C*radius}
+	hexCoords := make([]HexCoord, 0, 6*radius)
+
+	for side := range hexDirections {
+		direction := hexDirections[(side+2)%len(hexDirections)]
+
+		for step := int64(0); step < radius; step++ {
+			hexCoords = append(hexCoords, hexCoord)
+			hexCoord = HexCoord{R: hexCoord.R + direction.R, C: hexCoord.C + direction.C}
+		}
+	}
+
+	return hexCoords
+}
+
+// HexSpiral returns the center and rings around it up to the radius
+func HexSpiral(center HexCoord, radius int64) []HexCoord {
+	hexCoords := []HexCoord{center}
+
+	for ring := int64(1); ring <= radius; ring++ {
+		hexCoords = append(hexCoords, HexRing(center, ring)...)
+	}
+
+	return hexCoords
+}
+
+// IntersectionsWithinRoads returns intersections reachable from the intersection by at most roads paths
+// and the number of paths to them. Intersections canPass returns false for are not reached, nil canPass passes everywhere.
+// Negative roads reach nothing
+func IntersectionsWithinRoads(
+	calculator HexagonGridCalculator,
+	from IntersectionCoord,
+	roads int64,
+	canPass func(intersectionCoord IntersectionCoord) bool,
+) map[IntersectionCoord]int64 {
+	if roads < 0 {
+		return map[IntersectionCoord]int64{}
+	}
+
+	distances, _ := walkIntersections(calculator, from, roads, canPass, nil)
+
+	return distances
+}
+
+// IntersectionsShortestPath returns intersections from one to another along at most maxRoads paths, both of them included.
+// Intersections canPass returns false for are neither reached nor passed, nil canPass passes everywhere. The search is bounded by maxRoads,
+// so false is returned for an unreachable intersection, negative maxRoads finds nothing
+func IntersectionsShortestPath(
+	calculator HexagonGridCalculator,
+	from, to IntersectionCoord,
+	maxRoads int64,
+	canPass func(intersectionCoord IntersectionCoord) bool,
+) ([]IntersectionCoord, bool) {
+	if maxRoads < 0 {
+		return nil, false
+	}
+
+	_, previous := walkIntersections(calculator, from, maxRoads, canPass, &to)
+
+	if _, found := previous[to]; !found && from != to {
+		return nil, false
+	}
+
+	path := []IntersectionCoord{to}
+	for intersectionCoord := to; intersectionCoord != from; {
+		intersectionCoord = previous[intersectionCoord]
+		path = append([]IntersectionCoord{intersectionCoord}, path...)
+	}
+
+	return path, true
+}
+
+// walkIntersections goes breadth first from the intersection up to maxRoads paths, the walk stops at the target.
+// There is no unbounded mode, the grid has no bounds, so negative maxRoads walks no further than the intersection
+func walkIntersections(
+	calculator HexagonGridCalculator,
+	from IntersectionCoord,
+	maxRoads int64,
+	canPass func(intersectionCoord IntersectionCoord) bool,
+	target *IntersectionCoord,
+) (map[IntersectionCoord]int64, map[IntersectionCoord]IntersectionCoord) {
+	distances := map[IntersectionCoord]int64{from: 0}
+	previous := make(map[IntersectionCoord]IntersectionCoord)
+	queue := []IntersectionCoord{from}
+
+	for len(queue) > 0 {
+		intersectionCoord := queue[0]
+		queue = queue[1:]
+
+		if target != nil && intersectionCoord == *target {
+			break
+		}
+
+		if distances[intersectionCoord] >= maxRoads {
+			continue
+		}
+
+		for _, adjacentIntersectionCoord := range calculator.IntersectionAdjacentIntersections(intersectionCoord) {
+			if _, visited := distances[adjacentIntersectionCoord]; visited {
+				continue
+			}
+
+			if canPass != nil && !canPass(adjacentIntersectionCoord) {
+				continue
+			}
+
+			distances[adjacentIntersectionCoord] = distances[intersectionCoord] + 1
+			previous[adjacentIntersectionCoord] = intersectionCoord
+			queue = append(queue, adjacentIntersectionCoord)
+		}
+	}
+
+	return distances, previous
+}
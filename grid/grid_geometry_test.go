@@ -0,0 +1,184 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexNeighbours(t *testing.T) {
+	for _, calculator := range []HexagonGridCalculator{HexagonGridWithOffsetCoordsCalculator{}, HexagonGridWithCubeCoordsCalculator{}} {
+		assert.ElementsMatch(t, []HexCoord{
+			{R: 1, C: 1}, {R: 1, C: 2}, {R: 2, C: 3}, {R: 3, C: 3}, {R: 3, C: 2}, {R: 2, C: 1},
+		}, HexNeighbours(calculator, HexCoord{R: 2, C: 2}))
+
+		for _, neighbour := range HexNeighbours(calculator, HexCoord{R: -3, C: 5}) {
+			assert.Equal(t, int64(1), HexDistance(HexCoord{R: -3, C: 5}, neighbour))
+		}
+	}
+}
+
+func TestHexRing(t *testing.T) {
+	center := HexCoord{R: 2, C: 2}
+
+	assert.Equal(t, []HexCoord{center}, HexRing(center, 0))
+	assert.Equal(t, []HexCoord{
+		{R: 1, C: 1}, {R: 1, C: 2}, {R: 2, C: 3}, {R: 3, C: 3}, {R: 3, C: 2}, {R: 2, C: 1},
+	}, HexRing(center, 1))
+
+	ring := HexRing(center, 2)
+	assert.Len(t, ring, 12)
+	assert.Equal(t, HexCoord{R: 0, C: 0}, ring[0])
+
+	for _, hexCoord := range ring {
+		assert.Equal(t, int64(2), HexDistance(center, hexCoord))
+	}
+}
+
+// HexDistance and HexRing take no calculator, they must agree with adjacency of every calculator
+func TestHexRing_MatchesCalculators(t *testing.T) {
+	center := HexCoord{R: -1, C: 3}
+
+	for _, calculator := range []HexagonGridCalculator{HexagonGridWithOffsetCoordsCalculator{}, HexagonGridWithCubeCoordsCalculator{}} {
+		assert.ElementsMatch(t, HexNeighbours(calculator, center), HexRing(center, 1))
+
+		for radius := int64(1); radius <= 3; radius++ {
+			ring := HexRing(center, radius)
+
+			// consecutive hexes of the ring are neighbours, the last one closes the ring
+			for i, hexCoord := range ring {
+				assert.Contains(t, HexNeighbours(calculator, hexCoord), ring[(i+1)%len(ring)])
+			}
+
+			for _, hexCoord := range ring {
+				for _, neighbour := range HexNeighbours(calculator, hexCoord) {
+					assert.Equal(t, int64(1), HexDistance(hexCoord, neighbour))
+				}
+			}
+		}
+	}
+}
+
+func TestHexSpiral(t *testing.T) {
+	spiral := HexSpiral(HexCoord{R: 2, C: 2}, 2)
+
+	// the base board
+	assert.Len(t, spiral, 19)
+	assert.Equal(t, HexCoord{R: 2, C: 2}, spiral[0])
+
+	visited := make(map[HexCoord]bool)
+	for _, hexCoord := range spiral {
+		assert.False(t, visited[hexCoord])
+		visited[hexCoord] = true
+	}
+}
+
+func TestIntersectionsWithinRoads(t *testing.T) {
+	from := IntersectionCoord{R: 2, C: 2, D: R}
+	canPass := func(IntersectionCoord) bool { return true }
+
+	for _, calculator := range []HexagonGridCalculator{HexagonGridWithOffsetCoordsCalculator{}, HexagonGridWithCubeCoordsCalculator{}} {
+		assert.Equal(t, map[IntersectionCoord]int64{from: 0}, IntersectionsWithinRoads(calculator, from, 0, canPass))
+
+		// every intersection has three neighbours and there are no cycles shorter than six roads
+		assert.Len(t, IntersectionsWithinRoads(calculator, from, 1, canPass), 4)
+		assert.Len(t, IntersectionsWithinRoads(calculator, from, 2, canPass), 10)
+
+		assert.Empty(t, IntersectionsWithinRoads(calculator, from, -1, canPass))
+		assert.Len(t, IntersectionsWithinRoads(calculator, from, 2, nil), 10)
+
+		blocked := IntersectionCoord{R: 2, C: 3, D: L}
+		intersections := IntersectionsWithinRoads(calculator, from, 2, func(intersectionCoord IntersectionCoord) bool {
+			return intersectionCoord != blocked
+		})
+		assert.Len(t, intersections, 7)
+		assert.NotContains(t, intersections, blocked)
+	}
+}
+
+func TestIntersectionsShortestPath(t *testing.T) {
+	from := IntersectionCoord{R: 2, C: 2, D: R}
+	to := IntersectionCoord{R: 2, C: 2, D: L}
+
+	// roads of the player in the base game
+	const maxRoads = 15
+
+	// intersections around the base board
+	canPass := func(intersectionCoord IntersectionCoord) bool {
+		for _, hexCoord := range (HexagonGridWithOffsetCoordsCalculator{}).IntersectionAdjacentHexes(intersectionCoord) {
+			if HexDistance(hexCoord, HexCoord{R: 2, C: 2}) <= 2 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, calculator := range []HexagonGridCalculator{HexagonGridWithOffsetCoordsCalculator{}, HexagonGridWithCubeCoordsCalculator{}} {
+		path, ok := IntersectionsShortestPath(calculator, from, from, 0, canPass)
+		assert.True(t, ok)
+		assert.Equal(t, []IntersectionCoord{from}, path)
+
+		// opposite corners of the hex
+		path, ok = IntersectionsShortestPath(calculator, from, to, maxRoads, canPass)
+		assert.True(t, ok)
+		assert.Len(t, path, 4)
+		assert.Equal(t, from, path[0])
+		assert.Equal(t, to, path[3])
+
+		for i := 1; i < len(path); i++ {
+			assert.Contains(t, calculator.IntersectionAdjacentIntersections(path[i-1]), path[i])
+		}
+
+		// both ways around the hex are blocked, the road goes around the neighbour hex
+		blocked := map[IntersectionCoord]bool{path[1]: true}
+		for _, intersectionCoord := range calculator.HexAdjacentIntersections(HexCoord{R: 2, C: 2}) {
+			if intersectionCoord != from && intersectionCoord != to && !blocked[intersectionCoord] {
+				if _, isNeighbour := IntersectionsWithinRoads(calculator, from, 1, canPass)[intersectionCoord]; isNeighbour {
+					blocked[intersectionCoord] = true
+				}
+			}
+		}
+
+		path, ok = IntersectionsShortestPath(calculator, from, to, maxRoads, func(intersectionCoord IntersectionCoord) bool {
+			return !blocked[intersectionCoord] && canPass(intersectionCoord)
+		})
+		assert.True(t, ok)
+		assert.Greater(t, len(path), 4)
+
+		// the intersection is closed from every side
+		_, ok = IntersectionsShortestPath(calculator, from, to, maxRoads, func(intersectionCoord IntersectionCoord) bool {
+			_, isNeighbour := IntersectionsWithinRoads(calculator, from, 1, canPass)[intersectionCoord]
+			return !isNeighbour && canPass(intersectionCoord)
+		})
+		assert.False(t, ok)
+
+		// the path is longer than the roads
+		_, ok = IntersectionsShortestPath(calculator, from, to, 2, canPass)
+		assert.False(t, ok)
+	}
+}
+
+func TestIntersectionsShortestPath_Unreachable(t *testing.T) {
+	from := IntersectionCoord{R: 2, C: 2, D: R}
+	to := IntersectionCoord{R: 2, C: 2, D: L}
+
+	for _, calculator := range []HexagonGridCalculator{HexagonGridWithOffsetCoordsCalculator{}, HexagonGridWithCubeCoordsCalculator{}} {
+		// the grid has no bounds and the intersection is closed from every side
+		closed := IntersectionsWithinRoads(calculator, to, 1, func(IntersectionCoord) bool { return true })
+
+		path, ok := IntersectionsShortestPath(calculator, from, to, 15, func(intersectionCoord IntersectionCoord) bool {
+			_, isClosed := closed[intersectionCoord]
+			return !isClosed
+		})
+		assert.False(t, ok)
+		assert.Nil(t, path)
+
+		_, ok = IntersectionsShortestPath(calculator, from, to, -1, func(IntersectionCoord) bool { return true })
+		assert.False(t, ok)
+
+		path, ok = IntersectionsShortestPath(calculator, from, to, 3, nil)
+		assert.True(t, ok)
+		assert.Len(t, path, 4)
+	}
+}